@@ -0,0 +1,92 @@
+package random
+
+// Alias draws indices from a static weight table in O(1) time using Vose's alias method.
+// The table is built once by NewAlias; drawing does not allocate, so a single Alias
+// can serve millions of draws from any *Random. An Alias is immutable and safe for
+// concurrent use, as long as each goroutine draws with its own *Random.
+type Alias struct {
+	prob  []float64 // probability of keeping column i instead of taking its alias
+	alias []int
+}
+
+// NewAlias builds an Alias for weights. The probability of drawing index i is
+// w[i] / sum(w); zero weights are never drawn. The table holds float64
+// probabilities for every weight type, so integer weights above 2^53 are
+// rounded; use WeightedChoice where large integer weights must be drawn exactly.
+// It returns an error if weights is empty, contains negative or non-finite values,
+// or sums to zero or overflows.
+func NewAlias[W Number](weights []W) (*Alias, error) {
+	total, err := sumWeights(weights)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(weights)
+	a := &Alias{
+		prob:  make([]float64, n),
+		alias: make([]int, n),
+	}
+
+	// Scale the weights so that they average 1, then pair every column below 1
+	// with a column above 1 that tops it up.
+	scaled := make([]float64, n)
+	small := make([]int, 0, n)
+	large := make([]int, 0, n)
+	lastPositive := 0
+	for i, w := range weights {
+		scaled[i] = float64(w) / total * float64(n) // divide first, so large weights do not overflow
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+		if w > 0 {
+			lastPositive = i
+		}
+	}
+
+	for len(small) > 0 && len(large) > 0 {
+		l := small[len(small)-1]
+		small = small[:len(small)-1]
+		g := large[len(large)-1]
+		large = large[:len(large)-1]
+
+		a.prob[l] = scaled[l]
+		a.alias[l] = g
+		scaled[g] = (scaled[g] + scaled[l]) - 1
+		if scaled[g] < 1 {
+			small = append(small, g)
+		} else {
+			large = append(large, g)
+		}
+	}
+
+	// Whatever remains is 1 up to rounding error.
+	for _, g := range large {
+		a.prob[g] = 1
+		a.alias[g] = g
+	}
+	for _, l := range small {
+		if weights[l] > 0 {
+			a.prob[l] = 1
+			a.alias[l] = l
+		} else {
+			a.alias[l] = lastPositive // never draw a zero weight
+		}
+	}
+	return a, nil
+}
+
+// Len returns the number of weights in the table.
+func (a *Alias) Len() int {
+	return len(a.prob)
+}
+
+// Sample draws an index in [0, a.Len()-1] using r.
+func (a *Alias) Sample(r *Random) int {
+	i := int(r.uint64n(uint64(len(a.prob))))
+	if r.rand.Float64() < a.prob[i] {
+		return i
+	}
+	return a.alias[i]
+}
@@ -0,0 +1,137 @@
+package random_test
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/bofry/random"
+)
+
+// checkAliasDistribution draws from a and runs a chi-square test against probs.
+func checkAliasDistribution(t *testing.T, a *random.Alias, probs []float64) {
+	t.Helper()
+	const rounds = 200000
+
+	rng := random.New(rand.NewSource(seed))
+	counts := make([]int, a.Len())
+	for i := 0; i < rounds; i++ {
+		counts[a.Sample(rng)]++
+	}
+
+	checkChiSquare(t, "Alias", counts, probs)
+}
+
+// TestAlias tests that Alias draws indices with probability proportional to the weights for every weight type.
+func TestAlias(t *testing.T) {
+	probs := []float64{0.1, 0.2, 0, 0.3, 0.4}
+
+	testCases := []struct {
+		name string
+		new  func() (*random.Alias, error)
+	}{
+		{"Float64", func() (*random.Alias, error) { return random.NewAlias([]float64{1, 2, 0, 3, 4}) }},
+		{"Float32", func() (*random.Alias, error) { return random.NewAlias([]float32{0.5, 1, 0, 1.5, 2}) }},
+		{"Uint64", func() (*random.Alias, error) { return random.NewAlias([]uint64{1, 2, 0, 3, 4}) }},
+		{"Uint32", func() (*random.Alias, error) { return random.NewAlias([]uint32{1, 2, 0, 3, 4}) }},
+		{"Int64", func() (*random.Alias, error) { return random.NewAlias([]int64{10, 20, 0, 30, 40}) }},
+		{"Int32", func() (*random.Alias, error) { return random.NewAlias([]int32{1, 2, 0, 3, 4}) }},
+		{"Int", func() (*random.Alias, error) { return random.NewAlias([]int{1, 2, 0, 3, 4}) }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a, err := tc.new()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if a.Len() != len(probs) {
+				t.Fatalf("expected Len %d, got %d", len(probs), a.Len())
+			}
+			checkAliasDistribution(t, a, probs)
+		})
+	}
+}
+
+// TestAliasLargeWeights tests finite weights whose product with the table size overflows.
+func TestAliasLargeWeights(t *testing.T) {
+	a, err := random.NewAlias([]float64{8e307, 8e307, 1e307})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAliasDistribution(t, a, []float64{8, 8, 1})
+}
+
+// TestAliasLargeTable tests a reel-strip sized table with skewed weights.
+func TestAliasLargeTable(t *testing.T) {
+	weights := make([]int, 300)
+	probs := make([]float64, len(weights))
+	var total float64
+	for i := range weights {
+		weights[i] = (i%7)*(i%7) + i%3
+		total += float64(weights[i])
+	}
+	for i, w := range weights {
+		probs[i] = float64(w) / total
+	}
+
+	a, err := random.NewAlias(weights)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAliasDistribution(t, a, probs)
+}
+
+// TestAliasErrors tests that NewAlias rejects invalid weight tables.
+func TestAliasErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		new  func() (*random.Alias, error)
+		err  error
+	}{
+		{"Empty", func() (*random.Alias, error) { return random.NewAlias([]float64{}) }, random.ErrEmptyWeights},
+		{"Negative", func() (*random.Alias, error) { return random.NewAlias([]int{1, -1}) }, random.ErrNegativeWeight},
+		{"ZeroTotal", func() (*random.Alias, error) { return random.NewAlias([]uint32{0, 0}) }, random.ErrZeroTotal},
+		{"NaN", func() (*random.Alias, error) { return random.NewAlias([]float64{math.NaN()}) }, random.ErrNonFiniteWeight},
+		{"Overflow", func() (*random.Alias, error) { return random.NewAlias([]float64{math.MaxFloat64, math.MaxFloat64}) }, random.ErrWeightOverflow},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.new(); !errors.Is(err, tc.err) {
+				t.Errorf("expected error %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+// TestAliasAllocs tests that drawing from an Alias does not allocate.
+func TestAliasAllocs(t *testing.T) {
+	a, err := random.NewAlias([]float32{2, 2, 4, 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rng := random.New(rand.NewSource(seed))
+	if allocs := testing.AllocsPerRun(1000, func() { a.Sample(rng) }); allocs != 0 {
+		t.Errorf("Sample allocated %v times per run, expected 0", allocs)
+	}
+}
+
+func Benchmark_Alias(b *testing.B) {
+	a, _ := random.NewAlias([]float64{2, 2, 4, 2})
+	for n := b.N; n > 0; n-- {
+		a.Sample(rng)
+	}
+}
+
+func Benchmark_Alias_300(b *testing.B) {
+	ws := make([]float64, 300)
+	for i := range ws {
+		ws[i] = float64(i%7 + 1)
+	}
+	a, _ := random.NewAlias(ws)
+	for n := b.N; n > 0; n-- {
+		a.Sample(rng)
+	}
+}
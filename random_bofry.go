@@ -1,25 +1,76 @@
 package random
 
 import (
-	"math/rand"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"sort"
+	"sync"
 )
 
 // Uint64n returns a non-negative pseudo-random uint64 value in [0, n).
+// The result is unbiased for every n.
 // Panics if n <= 0.
 func (r *Random) Uint64n(n uint64) uint64 {
-	if n <= 0 {
+	if n == 0 {
 		panic("invalid argument to Uint64n")
 	}
-	return r.rand.Uint64() % n
+	return r.uint64n(n)
 }
 
 // Uint32n returns a non-negative pseudo-random uint32 value in [0, n).
+// The result is unbiased for every n.
 // Panics if n <= 0.
 func (r *Random) Uint32n(n uint32) uint32 {
-	if n <= 0 {
+	if n == 0 {
 		panic("invalid argument to Uint32n")
 	}
-	return r.rand.Uint32() % n
+	return r.uint32n(n)
+}
+
+// uint64n returns a pseudo-random uint64 value in [0, n) for n > 0 using
+// Lemire's nearly-divisionless rejection method (https://arxiv.org/abs/1805.10941).
+// The division computing the rejection threshold only runs when the low half of
+// the product lands in the biased zone, which happens with probability < n/2^64.
+func (r *Random) uint64n(n uint64) uint64 {
+	if n&(n-1) == 0 { // n is a power of two
+		return r.rand.Uint64() & (n - 1)
+	}
+	hi, lo := bits.Mul64(r.rand.Uint64(), n)
+	if lo < n {
+		thresh := -n % n
+		for lo < thresh {
+			hi, lo = bits.Mul64(r.rand.Uint64(), n)
+		}
+	}
+	return hi
+}
+
+// uint64r returns a pseudo-random uint64 value in [0, span].
+// Unlike uint64n it covers the whole domain when span is math.MaxUint64.
+func (r *Random) uint64r(span uint64) uint64 {
+	if span == math.MaxUint64 {
+		return r.rand.Uint64()
+	}
+	return r.uint64n(span + 1)
+}
+
+// uint32n returns a pseudo-random uint32 value in [0, n) for n > 0 using
+// Lemire's nearly-divisionless rejection method.
+func (r *Random) uint32n(n uint32) uint32 {
+	if n&(n-1) == 0 { // n is a power of two
+		return r.rand.Uint32() & (n - 1)
+	}
+	prod := uint64(r.rand.Uint32()) * uint64(n)
+	if low := uint32(prod); low < n {
+		thresh := -n % n
+		for low < thresh {
+			prod = uint64(r.rand.Uint32()) * uint64(n)
+			low = uint32(prod)
+		}
+	}
+	return uint32(prod >> 32)
 }
 
 // Float64n returns a pseudo-random float64 value in [0.0, n).
@@ -27,6 +78,16 @@ func (r *Random) Float64n(n float64) float64 {
 	return n * r.rand.Float64()
 }
 
+// float64Open returns a pseudo-random float64 in the open interval (0.0, 1.0),
+// so that its logarithm and reciprocal are always finite.
+func (r *Random) float64Open() float64 {
+	for {
+		if f := r.rand.Float64(); f != 0 {
+			return f
+		}
+	}
+}
+
 // Float32n returns a pseudo-random float32 value in [0.0, n).
 // Panics if n <= 0.
 func (r *Random) Float32n(n float32) float32 {
@@ -40,163 +101,372 @@ func (r *Random) Float32n(n float32) float32 {
 // Weighted Random Selection
 // ----------------------------------------------------------------------------
 
+// Errors returned by the Try*w weighted selection functions. The panicking *w
+// functions panic with the same errors. Errors concerning a single weight are
+// wrapped with its index and can be tested with errors.Is.
+var (
+	ErrEmptyWeights    = errors.New("random: empty weights slice")
+	ErrNegativeWeight  = errors.New("random: negative weight")
+	ErrZeroTotal       = errors.New("random: weights sum to zero")
+	ErrNonFiniteWeight = errors.New("random: non-finite weight")
+	ErrWeightOverflow  = errors.New("random: weights sum overflows")
+	ErrLengthMismatch  = errors.New("random: items and weights differ in length")
+)
+
+// Number is the set of numeric types accepted as weights.
+type Number interface {
+	~float64 | ~float32 | ~uint64 | ~uint32 | ~int64 | ~int32 | ~int
+}
+
+// Ordered is the set of types whose values can be sorted, used as map keys by WeightedKey.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
 // Float64w randomly picks an index in the range [0, len(w)-1] based on the weights in slice w.
-// The probability of picking index i is w[i] / sum(w).
-// Panics if w is empty or contains non-positive values.
+// The probability of picking index i is w[i] / sum(w); zero weights are never picked.
+// Panics if w is empty, contains negative or non-finite values, or sums to zero or overflows.
 func (r *Random) Float64w(w []float64) int {
-	return weightedRandomIndexFloat64(r.rand, w)
+	return mustWeightedIndex(weightedRandomIndex(r, w))
 }
 
 // Float32w randomly picks an index in the range [0, len(w)-1] based on the weights in slice w.
-// The probability of picking index i is w[i] / sum(w).
-// Panics if w is empty or contains non-positive values.
+// The probability of picking index i is w[i] / sum(w); zero weights are never picked.
+// Panics if w is empty, contains negative or non-finite values, or sums to zero or overflows.
 func (r *Random) Float32w(w []float32) int {
-	return weightedRandomIndexFloat32(r.rand, w)
+	return mustWeightedIndex(weightedRandomIndex(r, w))
 }
 
 // Uint64w randomly picks an index in the range [0, len(w)-1] based on the weights in slice w.
-// The probability of picking index i is w[i] / sum(w).
-// Panics if w is empty.
+// The probability of picking index i is w[i] / sum(w); zero weights are never picked.
+// The selection is exact even when sum(w) exceeds math.MaxUint64.
+// Panics with ErrZeroTotal if all weights are zero, or ErrEmptyWeights if w is empty.
 func (r *Random) Uint64w(w []uint64) int {
-	return weightedRandomIndexUint64(r.rand, w)
+	return mustWeightedIndex(weightedRandomIndex(r, w))
 }
 
 // Uint32w randomly picks an index in the range [0, len(w)-1] based on the weights in slice w.
-// The probability of picking index i is w[i] / sum(w).
-// Panics if w is empty.
+// The probability of picking index i is w[i] / sum(w); zero weights are never picked.
+// Panics with ErrZeroTotal if all weights are zero, or ErrEmptyWeights if w is empty.
 func (r *Random) Uint32w(w []uint32) int {
-	return weightedRandomIndexUint32(r.rand, w)
+	return mustWeightedIndex(weightedRandomIndex(r, w))
 }
 
 // Int64w randomly picks an index in the range [0, len(w)-1] based on the weights in slice w.
-// The probability of picking index i is w[i] / sum(w).
-// Panics if w is empty or contains non-positive values.
+// The probability of picking index i is w[i] / sum(w); zero weights are never picked.
+// The weights are summed as float64, so that a seeded generator repeats the draws of
+// earlier releases; use WeightedChoice to select large integer weights exactly.
+// Panics if w is empty, contains negative values or sums to zero.
 func (r *Random) Int64w(w []int64) int {
-	return weightedRandomIndexInt64(r.rand, w)
+	return mustWeightedIndex(weightedRandomIndexFloat(r, w))
 }
 
 // Int32w randomly picks an index in the range [0, len(w)-1] based on the weights in slice w.
-// The probability of picking index i is w[i] / sum(w).
-// Panics if w is empty or contains non-positive values.
+// The probability of picking index i is w[i] / sum(w); zero weights are never picked.
+// The weights are summed as float64, so that a seeded generator repeats the draws of
+// earlier releases; use WeightedChoice to select large integer weights exactly.
+// Panics if w is empty, contains negative values or sums to zero.
 func (r *Random) Int32w(w []int32) int {
-	return weightedRandomIndexInt32(r.rand, w)
+	return mustWeightedIndex(weightedRandomIndexFloat(r, w))
 }
 
 // Intw randomly picks an index in the range [0, len(w)-1] based on the weights in slice w.
-// The probability of picking index i is w[i] / sum(w).
-// Panics if w is empty or contains non-positive values.
+// The probability of picking index i is w[i] / sum(w); zero weights are never picked.
+// The weights are summed as float64, so that a seeded generator repeats the draws of
+// earlier releases; use WeightedChoice to select large integer weights exactly.
+// Panics if w is empty, contains negative values or sums to zero.
 func (r *Random) Intw(w []int) int {
-	return weightedRandomIndexInt(r.rand, w)
+	return mustWeightedIndex(weightedRandomIndexFloat(r, w))
 }
 
-// weightedRandomIndexFloat64 selects a random index based on float64 weights.
-func weightedRandomIndexFloat64(rng *rand.Rand, weights []float64) int {
-	if len(weights) == 0 {
-		panic("empty weights slice")
+// TryFloat64w is like Float64w but returns an error instead of panicking on invalid weights.
+func (r *Random) TryFloat64w(w []float64) (int, error) {
+	return weightedRandomIndex(r, w)
+}
+
+// TryFloat32w is like Float32w but returns an error instead of panicking on invalid weights.
+func (r *Random) TryFloat32w(w []float32) (int, error) {
+	return weightedRandomIndex(r, w)
+}
+
+// TryUint64w is like Uint64w but returns an error instead of panicking on invalid weights.
+func (r *Random) TryUint64w(w []uint64) (int, error) {
+	return weightedRandomIndex(r, w)
+}
+
+// TryUint32w is like Uint32w but returns an error instead of panicking on invalid weights.
+func (r *Random) TryUint32w(w []uint32) (int, error) {
+	return weightedRandomIndex(r, w)
+}
+
+// TryInt64w is like Int64w but returns an error instead of panicking on invalid weights.
+func (r *Random) TryInt64w(w []int64) (int, error) {
+	return weightedRandomIndexFloat(r, w)
+}
+
+// TryInt32w is like Int32w but returns an error instead of panicking on invalid weights.
+func (r *Random) TryInt32w(w []int32) (int, error) {
+	return weightedRandomIndexFloat(r, w)
+}
+
+// TryIntw is like Intw but returns an error instead of panicking on invalid weights.
+func (r *Random) TryIntw(w []int) (int, error) {
+	return weightedRandomIndexFloat(r, w)
+}
+
+// WeightedChoice randomly picks an element of items based on the weights in slice weights.
+// The probability of picking items[i] is weights[i] / sum(weights); zero weights are never picked.
+// Integer weights are selected exactly. It does not allocate.
+// Panics if items and weights differ in length, or if weights is invalid as for Float64w.
+func WeightedChoice[T any, W Number](r *Random, items []T, weights []W) T {
+	item, err := TryWeightedChoice(r, items, weights)
+	if err != nil {
+		panic(err)
 	}
-	if len(weights) == 1 {
-		return 0
+	return item
+}
+
+// TryWeightedChoice is like WeightedChoice but returns an error instead of panicking.
+func TryWeightedChoice[T any, W Number](r *Random, items []T, weights []W) (T, error) {
+	var zero T
+	if len(items) != len(weights) {
+		return zero, ErrLengthMismatch
 	}
+	i, err := weightedRandomIndex(r, weights)
+	if err != nil {
+		return zero, err
+	}
+	return items[i], nil
+}
 
-	var totalWeight float64
-	for _, w := range weights {
-		if w <= 0 {
-			panic("weights must be positive")
+// WeightedKey randomly picks a key of m based on its weight. The probability of picking
+// key k is m[k] / sum(m); keys with zero weight are never picked. Keys are visited in
+// sorted order, so the result only depends on the state of r and the contents of m,
+// not on map iteration order. The keys are sorted on every call, into a buffer that
+// is reused across calls; draw from a WeightedKeys to sort them only once, or to
+// use keys without a natural order.
+// Panics if m is invalid as for Float64w.
+func WeightedKey[K Ordered, W Number](r *Random, m map[K]W) K {
+	key, err := TryWeightedKey(r, m)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// TryWeightedKey is like WeightedKey but returns an error instead of panicking.
+// Errors concerning a single weight are wrapped with its key.
+func TryWeightedKey[K Ordered, W Number](r *Random, m map[K]W) (K, error) {
+	b, ok := weightedKeyBuffers.Get().(*weightedKeyBuffer[K, W])
+	if !ok {
+		b = new(weightedKeyBuffer[K, W])
+	}
+	defer weightedKeyBuffers.Put(b)
+
+	b.keys = b.keys[:0]
+	for k := range m {
+		b.keys = append(b.keys, k)
+	}
+	sort.Sort(b)
+
+	var zero K
+	b.weights = b.weights[:0]
+	for _, k := range b.keys {
+		w := m[k]
+		if err := checkWeight(float64(w)); err != nil {
+			return zero, fmt.Errorf("%w at key %v", err, k)
 		}
-		totalWeight += w
+		b.weights = append(b.weights, w)
 	}
+	i, err := weightedRandomIndex(r, b.weights)
+	if err != nil {
+		return zero, err
+	}
+	return b.keys[i], nil
+}
 
-	target := rng.Float64() * totalWeight
-	for i, w := range weights {
-		if target < w {
-			return i
+// weightedKeyBuffers holds the sorted keys and weights of earlier WeightedKey calls,
+// so that repeated draws do not allocate. Buffers of other key or weight types are
+// replaced.
+var weightedKeyBuffers sync.Pool
+
+// weightedKeyBuffer sorts keys for TryWeightedKey.
+type weightedKeyBuffer[K Ordered, W Number] struct {
+	keys    []K
+	weights []W
+}
+
+func (b *weightedKeyBuffer[K, W]) Len() int           { return len(b.keys) }
+func (b *weightedKeyBuffer[K, W]) Less(i, j int) bool { return b.keys[i] < b.keys[j] }
+func (b *weightedKeyBuffer[K, W]) Swap(i, j int)      { b.keys[i], b.keys[j] = b.keys[j], b.keys[i] }
+
+// WeightedKeys draws keys of a map based on their weights, for any comparable key
+// type. The keys are sorted once by NewWeightedKeys, so the drawn keys only depend
+// on the state of the *Random and the contents of the map, and drawing does not
+// allocate.
+type WeightedKeys[K comparable, W Number] struct {
+	keys    []K
+	weights []W
+}
+
+// NewWeightedKeys creates a WeightedKeys over the keys and weights of m, visiting the
+// keys in the order given by less. The map is copied.
+// It returns an error if m is invalid as for TryFloat64w; errors concerning a single
+// weight are wrapped with its key.
+func NewWeightedKeys[K comparable, W Number](m map[K]W, less func(a, b K) bool) (*WeightedKeys[K, W], error) {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return less(keys[i], keys[j]) })
+
+	weights := make([]W, len(keys))
+	for i, k := range keys {
+		if err := checkWeight(float64(m[k])); err != nil {
+			return nil, fmt.Errorf("%w at key %v", err, k)
 		}
-		target -= w
+		weights[i] = m[k]
+	}
+	if _, err := sumWeights(weights); err != nil {
+		return nil, err
 	}
-	return len(weights) - 1 // Should not reach here if weights are valid
+	return &WeightedKeys[K, W]{keys: keys, weights: weights}, nil
+}
+
+// Len returns the number of keys.
+func (w *WeightedKeys[K, W]) Len() int {
+	return len(w.keys)
+}
+
+// Key returns a key drawn using r.
+func (w *WeightedKeys[K, W]) Key(r *Random) K {
+	return w.keys[mustWeightedIndex(weightedRandomIndex(r, w.weights))]
 }
 
-// weightedRandomIndexFloat32 selects a random index based on float32 weights.
-func weightedRandomIndexFloat32(rng *rand.Rand, weights []float32) int {
-	// Convert float32 weights to float64
-	weights64 := make([]float64, len(weights))
+// sumWeights validates weights and returns their sum as a float64.
+func sumWeights[W Number](weights []W) (float64, error) {
+	if len(weights) == 0 {
+		return 0, ErrEmptyWeights
+	}
+
+	var totalWeight float64
 	for i, w := range weights {
-		weights64[i] = float64(w)
+		f := float64(w)
+		if err := checkWeight(f); err != nil {
+			return 0, fmt.Errorf("%w at index %d", err, i)
+		}
+		totalWeight += f
 	}
-	return weightedRandomIndexFloat64(rng, weights64)
+	if math.IsInf(totalWeight, 1) {
+		return 0, ErrWeightOverflow
+	}
+	if totalWeight == 0 {
+		return 0, ErrZeroTotal
+	}
+	return totalWeight, nil
 }
 
-// weightedRandomIndexUint64 selects a random index based on uint64 weights.
-func weightedRandomIndexUint64(rng *rand.Rand, weights []uint64) int {
-	if len(weights) == 0 {
-		panic("empty weights slice")
+// checkWeight returns an error if w cannot be used as a weight.
+func checkWeight(w float64) error {
+	if math.IsNaN(w) || math.IsInf(w, 0) {
+		return ErrNonFiniteWeight
 	}
-	if len(weights) == 1 {
-		return 0
+	if w < 0 {
+		return ErrNegativeWeight
+	}
+	return nil
+}
+
+// mustWeightedIndex panics if err is not nil.
+func mustWeightedIndex(i int, err error) int {
+	if err != nil {
+		panic(err)
 	}
+	return i
+}
 
-	var totalWeight uint64
-	for _, w := range weights {
-		totalWeight += w
+// weightedRandomIndex selects a random index based on weights of any numeric type
+// without converting or copying the slice. Integer weights are selected exactly.
+func weightedRandomIndex[W Number](r *Random, weights []W) (int, error) {
+	if W(1)/2 != 0 { // W is a floating-point type
+		return weightedRandomIndexFloat(r, weights)
 	}
+	return weightedRandomIndexInteger(r, weights)
+}
 
-	// Scale random value to the total weight range
-	target := rng.Uint64() % totalWeight
+// weightedRandomIndexFloat selects a random index based on floating-point weights.
+func weightedRandomIndexFloat[W Number](r *Random, weights []W) (int, error) {
+	totalWeight, err := sumWeights(weights)
+	if err != nil {
+		return 0, err
+	}
+	if len(weights) == 1 {
+		return 0, nil // Consume no draw, as in earlier releases
+	}
 
+	last := 0
+	target := r.rand.Float64() * totalWeight
 	for i, w := range weights {
-		if target < w {
-			return i
+		f := float64(w)
+		if target < f {
+			return i, nil
 		}
-		target -= w
+		if f > 0 {
+			last = i
+		}
+		target -= f
 	}
-	return len(weights) - 1 // Should not reach here if weights are valid
+	return last, nil // Only reached through rounding; never pick a zero weight
 }
 
-// weightedRandomIndexUint32 selects a random index based on uint32 weights.
-func weightedRandomIndexUint32(rng *rand.Rand, weights []uint32) int {
-	// Convert uint32 weights to uint64
-	weights64 := make([]uint64, len(weights))
-	for i, w := range weights {
-		weights64[i] = uint64(w)
+// weightedRandomIndexInteger selects a random index based on integer weights.
+// The total weight is accumulated in 128 bits, so the selection stays exact
+// even when the weights sum beyond math.MaxUint64.
+func weightedRandomIndexInteger[W Number](r *Random, weights []W) (int, error) {
+	if len(weights) == 0 {
+		return 0, ErrEmptyWeights
 	}
-	return weightedRandomIndexUint64(rng, weights64)
-}
 
-// weightedRandomIndexInt64 selects a random index based on int64 weights.
-func weightedRandomIndexInt64(rng *rand.Rand, weights []int64) int {
-	// Convert int64 weights to float64
-	weightsF64 := make([]float64, len(weights))
+	var totalHi, totalLo, carry uint64
 	for i, w := range weights {
 		if w < 0 {
-			panic("weights must be positive")
+			return 0, fmt.Errorf("%w at index %d", ErrNegativeWeight, i)
 		}
-		weightsF64[i] = float64(w)
+		totalLo, carry = bits.Add64(totalLo, uint64(w), 0)
+		totalHi += carry
+	}
+	if totalHi == 0 && totalLo == 0 {
+		return 0, ErrZeroTotal
+	}
+	if len(weights) == 1 {
+		return 0, nil // Consume no draw, as in earlier releases
 	}
-	return weightedRandomIndexFloat64(rng, weightsF64)
-}
 
-// weightedRandomIndexInt32 selects a random index based on int32 weights.
-func weightedRandomIndexInt32(rng *rand.Rand, weights []int32) int {
-	// Convert int32 weights to int64
-	weights64 := make([]int64, len(weights))
+	targetHi, targetLo := r.uint128n(totalHi, totalLo)
+	var borrow uint64
 	for i, w := range weights {
-		if w < 0 {
-			panic("weights must be positive")
+		if targetHi == 0 && targetLo < uint64(w) {
+			return i, nil
 		}
-		weights64[i] = int64(w)
+		targetLo, borrow = bits.Sub64(targetLo, uint64(w), 0)
+		targetHi -= borrow
 	}
-	return weightedRandomIndexInt64(rng, weights64)
+	return len(weights) - 1, nil // Should not reach here if weights are valid
 }
 
-// weightedRandomIndexInt selects a random index based on int weights.
-func weightedRandomIndexInt(rng *rand.Rand, weights []int) int {
-	// Convert int weights to float64
-	weightsF64 := make([]float64, len(weights))
-	for i, w := range weights {
-		if w < 0 {
-			panic("weights must be positive")
+// uint128n returns a uniform 128-bit value in [0, hi:lo), which must not be zero.
+// The weighted selection functions call it with hi at most the number of weights,
+// so the rejection loop accepts with probability >= 1/2.
+func (r *Random) uint128n(hi, lo uint64) (uint64, uint64) {
+	if hi == 0 {
+		return 0, r.uint64n(lo)
+	}
+	for {
+		xHi, xLo := r.uint64r(hi), r.rand.Uint64()
+		if xHi < hi || xLo < lo {
+			return xHi, xLo
 		}
-		weightsF64[i] = float64(w)
 	}
-	return weightedRandomIndexFloat64(rng, weightsF64)
 }
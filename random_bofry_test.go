@@ -1,6 +1,8 @@
 package random_test
 
 import (
+	"errors"
+	"math"
 	"math/rand"
 	"testing"
 
@@ -114,6 +116,8 @@ func TestPanicCases(t *testing.T) {
 		{"Int32w_NegativeWeight", func() { rng.Int32w([]int32{1, -2, 3}) }},
 		{"Intw_EmptySlice", func() { rng.Intw([]int{}) }},
 		{"Intw_NegativeWeight", func() { rng.Intw([]int{1, -2, 3}) }},
+		{"Float64w_ZeroTotal", func() { rng.Float64w([]float64{0, 0}) }},
+		{"Uint64w_ZeroTotal", func() { rng.Uint64w([]uint64{0, 0}) }},
 	}
 
 	for _, tc := range panicTestCases {
@@ -128,6 +132,176 @@ func TestPanicCases(t *testing.T) {
 	}
 }
 
+// TestTryWeighted tests that the Try*w functions report invalid weights with the expected errors.
+func TestTryWeighted(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	safe := random.NewSafeRandom(rand.NewSource(seed))
+
+	testCases := []struct {
+		name     string
+		function func() (int, error)
+		err      error
+	}{
+		{"Float64w_Valid", func() (int, error) { return rng.TryFloat64w([]float64{1, 2, 3}) }, nil},
+		{"Float64w_Empty", func() (int, error) { return rng.TryFloat64w(nil) }, random.ErrEmptyWeights},
+		{"Float64w_Negative", func() (int, error) { return rng.TryFloat64w([]float64{1, -2, 3}) }, random.ErrNegativeWeight},
+		{"Float64w_ZeroTotal", func() (int, error) { return rng.TryFloat64w([]float64{0, 0}) }, random.ErrZeroTotal},
+		{"Float64w_SingleZero", func() (int, error) { return rng.TryFloat64w([]float64{0}) }, random.ErrZeroTotal},
+		{"Float64w_NaN", func() (int, error) { return rng.TryFloat64w([]float64{1, math.NaN()}) }, random.ErrNonFiniteWeight},
+		{"Float64w_Inf", func() (int, error) { return rng.TryFloat64w([]float64{math.Inf(1), 1}) }, random.ErrNonFiniteWeight},
+		{"Float64w_Overflow", func() (int, error) { return rng.TryFloat64w([]float64{math.MaxFloat64, math.MaxFloat64}) }, random.ErrWeightOverflow},
+		{"Float32w_Valid", func() (int, error) { return rng.TryFloat32w([]float32{1, 2, 3}) }, nil},
+		{"Float32w_Empty", func() (int, error) { return rng.TryFloat32w([]float32{}) }, random.ErrEmptyWeights},
+		{"Float32w_Negative", func() (int, error) { return rng.TryFloat32w([]float32{-1}) }, random.ErrNegativeWeight},
+		{"Float32w_NaN", func() (int, error) { return rng.TryFloat32w([]float32{float32(math.NaN())}) }, random.ErrNonFiniteWeight},
+		{"Uint64w_Valid", func() (int, error) { return rng.TryUint64w([]uint64{1, 0, 3}) }, nil},
+		{"Uint64w_Empty", func() (int, error) { return rng.TryUint64w(nil) }, random.ErrEmptyWeights},
+		{"Uint64w_ZeroTotal", func() (int, error) { return rng.TryUint64w([]uint64{0, 0, 0}) }, random.ErrZeroTotal},
+		{"Uint32w_Valid", func() (int, error) { return rng.TryUint32w([]uint32{1, 2, 3}) }, nil},
+		{"Uint32w_Empty", func() (int, error) { return rng.TryUint32w(nil) }, random.ErrEmptyWeights},
+		{"Uint32w_ZeroTotal", func() (int, error) { return rng.TryUint32w([]uint32{0}) }, random.ErrZeroTotal},
+		{"Int64w_Valid", func() (int, error) { return rng.TryInt64w([]int64{1, 2, 3}) }, nil},
+		{"Int64w_Empty", func() (int, error) { return rng.TryInt64w(nil) }, random.ErrEmptyWeights},
+		{"Int64w_Negative", func() (int, error) { return rng.TryInt64w([]int64{1, -2, 3}) }, random.ErrNegativeWeight},
+		{"Int64w_ZeroTotal", func() (int, error) { return rng.TryInt64w([]int64{0, 0}) }, random.ErrZeroTotal},
+		{"Int32w_Valid", func() (int, error) { return rng.TryInt32w([]int32{1, 2, 3}) }, nil},
+		{"Int32w_Negative", func() (int, error) { return rng.TryInt32w([]int32{-1, 2}) }, random.ErrNegativeWeight},
+		{"Intw_Valid", func() (int, error) { return rng.TryIntw([]int{1, 2, 3}) }, nil},
+		{"Intw_Negative", func() (int, error) { return rng.TryIntw([]int{1, 2, -3}) }, random.ErrNegativeWeight},
+		{"Intw_ZeroTotal", func() (int, error) { return rng.TryIntw([]int{0}) }, random.ErrZeroTotal},
+		{"SafeRandom_Float64w_Negative", func() (int, error) { return safe.TryFloat64w([]float64{-1}) }, random.ErrNegativeWeight},
+		{"SafeRandom_Intw_Valid", func() (int, error) { return safe.TryIntw([]int{1, 2, 3}) }, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			index, err := tc.function()
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+			if err == nil && (index < 0 || index > 2) {
+				t.Errorf("returned out of range index: %d", index)
+			}
+		})
+	}
+}
+
+// TestWeightedZeroWeight tests that zero weights are never picked.
+func TestWeightedZeroWeight(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	for i := 0; i < 10000; i++ {
+		if index := rng.Float64w([]float64{0, 1, 0, 1, 0}); index%2 == 0 {
+			t.Fatalf("Float64w picked zero weight at index %d", index)
+		}
+		if index := rng.Intw([]int{0, 1, 0, 1, 0}); index%2 == 0 {
+			t.Fatalf("Intw picked zero weight at index %d", index)
+		}
+		if index := rng.Uint64w([]uint64{0, 1, 0, 1, 0}); index%2 == 0 {
+			t.Fatalf("Uint64w picked zero weight at index %d", index)
+		}
+	}
+}
+
+// TestUint64wLargeSum tests that Uint64w stays exact when the weights sum beyond math.MaxUint64.
+func TestUint64wLargeSum(t *testing.T) {
+	const rounds = 100000
+	rng := random.New(rand.NewSource(seed))
+
+	testCases := []struct {
+		name    string
+		weights []uint64
+		probs   []float64
+	}{
+		{"TwoMax", []uint64{math.MaxUint64, math.MaxUint64, 0}, []float64{0.5, 0.5, 0}},
+		{"Mixed", []uint64{math.MaxUint64, math.MaxUint64, 1 << 63}, []float64{0.4, 0.4, 0.2}},
+		{"Halves", []uint64{1 << 63, 1 << 63, 1 << 63}, []float64{1.0 / 3, 1.0 / 3, 1.0 / 3}},
+		{"Below Max", []uint64{math.MaxUint64 / 2, math.MaxUint64 / 4, math.MaxUint64 / 4}, []float64{0.5, 0.25, 0.25}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			counts := make([]int, len(tc.weights))
+			for i := 0; i < rounds; i++ {
+				index, err := rng.TryUint64w(tc.weights)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				counts[index]++
+			}
+			checkChiSquare(t, tc.name, counts, tc.probs)
+		})
+	}
+}
+
+// TestUint64wZeroTotal tests that all-zero integer weights panic with ErrZeroTotal instead of dividing by zero.
+func TestUint64wZeroTotal(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	for _, f := range []func(){
+		func() { rng.Uint64w([]uint64{0, 0, 0}) },
+		func() { rng.Uint32w([]uint32{0, 0}) },
+	} {
+		func() {
+			defer func() {
+				if err, ok := recover().(error); !ok || !errors.Is(err, random.ErrZeroTotal) {
+					t.Errorf("expected panic with ErrZeroTotal, got %v", err)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+// TestWeightedReplay tests that Int64w, Int32w, Intw and Float64w repeat the draws of
+// earlier releases for a given seed: the weights are summed as float64, a single
+// weight consumes no draw, and otherwise one Float64 is scanned against the weights.
+func TestWeightedReplay(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	ref := rand.New(rand.NewSource(seed))
+	replay := func(w []float64) int {
+		if len(w) == 1 {
+			return 0
+		}
+		var total float64
+		for _, v := range w {
+			total += v
+		}
+		target := ref.Float64() * total
+		for i, v := range w {
+			if target < v {
+				return i
+			}
+			target -= v
+		}
+		return len(w) - 1
+	}
+
+	weights := [][]int{{7}, {1, 2, 3}, {5, 1 << 30, 9, 1}, {100, 1}}
+	for i := 0; i < 1000; i++ {
+		w := weights[i%len(weights)]
+		f := make([]float64, len(w))
+		w64 := make([]int64, len(w))
+		w32 := make([]int32, len(w))
+		for j, v := range w {
+			f[j], w64[j], w32[j] = float64(v), int64(v), int32(v)
+		}
+
+		var got int
+		switch i % 4 {
+		case 0:
+			got = rng.Intw(w)
+		case 1:
+			got = rng.Int64w(w64)
+		case 2:
+			got = rng.Int32w(w32)
+		case 3:
+			got = rng.Float64w(f)
+		}
+		if want := replay(f); got != want {
+			t.Fatalf("draw %d with weights %v: expected %d, got %d", i, w, want, got)
+		}
+	}
+}
+
 // TestRandomNumberBounds tests if the generated numbers are within the expected bounds.
 func TestRandomNumberBounds(t *testing.T) {
 	rng := random.New(rand.NewSource(seed))
@@ -139,9 +313,11 @@ func TestRandomNumberBounds(t *testing.T) {
 		high     int64
 		count    int
 	}{
-		{"Int63n", rng.Int63n, 1, 100, 1000},
-		{"Int31n", func(n int64) int64 { return int64(rng.Int31n(int32(n))) }, 1, 100, 1000},
-		{"Intn", func(n int64) int64 { return int64(rng.Intn(int(n))) }, 1, 100, 1000},
+		{"Int63n", rng.Int63n, 0, 100, 1000},
+		{"Int31n", func(n int64) int64 { return int64(rng.Int31n(int32(n))) }, 0, 100, 1000},
+		{"Intn", func(n int64) int64 { return int64(rng.Intn(int(n))) }, 0, 100, 1000},
+		{"Uint64n", func(n int64) int64 { return int64(rng.Uint64n(uint64(n))) }, 0, 100, 1000},
+		{"Uint32n", func(n int64) int64 { return int64(rng.Uint32n(uint32(n))) }, 0, 100, 1000},
 	}
 	for _, c := range intCases {
 		t.Run(c.name, func(t *testing.T) {
@@ -162,8 +338,8 @@ func TestRandomNumberBounds(t *testing.T) {
 		high     float64
 		count    int
 	}{
-		{"Float64n", rng.Float64n, 1.0, 100.0, 1000},
-		{"Float32n", func(n float64) float64 { return float64(rng.Float32n(float32(n))) }, 1.0, 100.0, 1000},
+		{"Float64n", rng.Float64n, 0.0, 100.0, 1000},
+		{"Float32n", func(n float64) float64 { return float64(rng.Float32n(float32(n))) }, 0.0, 100.0, 1000},
 	}
 
 	for _, c := range floatCases {
@@ -178,6 +354,57 @@ func TestRandomNumberBounds(t *testing.T) {
 	}
 }
 
+// TestUintnUnbiased runs a chi-square test on Uint64n and Uint32n with n = 3*2^(w-2),
+// where taking the modulus would select the lowest third of the range twice as often.
+func TestUintnUnbiased(t *testing.T) {
+	const rounds = 300000
+	rng := random.New(rand.NewSource(seed))
+	safe := random.NewSafeRandom(rand.NewSource(seed))
+
+	testCases := []struct {
+		name     string
+		function func() int // returns the third of [0, n) the value falls in
+	}{
+		{"Uint64n", func() int { return int(rng.Uint64n(3<<62) >> 62) }},
+		{"Uint32n", func() int { return int(rng.Uint32n(3<<30) >> 30) }},
+		{"SafeRandom.Uint64n", func() int { return int(safe.Uint64n(3<<62) >> 62) }},
+		{"SafeRandom.Uint32n", func() int { return int(safe.Uint32n(3<<30) >> 30) }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			counts := make([]int, 3)
+			for i := 0; i < rounds; i++ {
+				counts[tc.function()]++
+			}
+			checkChiSquare(t, tc.name, counts, nil)
+		})
+	}
+}
+
+// TestUintnPanic tests that Uint64n and Uint32n panic on n == 0.
+func TestUintnPanic(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	panicTestCases := []struct {
+		name     string
+		function func()
+	}{
+		{"Uint64n", func() { rng.Uint64n(0) }},
+		{"Uint32n", func() { rng.Uint32n(0) }},
+	}
+
+	for _, tc := range panicTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s did not panic as expected", tc.name)
+				}
+			}()
+			tc.function()
+		})
+	}
+}
+
 // Helper functions for type conversion
 func float32sToFloat64s(input []float64) []float32 {
 	output := make([]float32, len(input))
@@ -0,0 +1,207 @@
+package random
+
+import "math"
+
+// Exponential returns an exponentially distributed float64 with the given rate (inverse mean).
+// Panics if rate <= 0.
+func (r *Random) Exponential(rate float64) float64 {
+	if !(rate > 0) {
+		panic("invalid argument to Exponential")
+	}
+	return -math.Log(r.float64Open()) / rate
+}
+
+// Gamma returns a gamma distributed float64 with the given shape and scale,
+// i.e. with mean shape*scale and variance shape*scale².
+// It uses the Marsaglia–Tsang method; shapes below 1 are boosted to shape+1
+// and corrected with U^(1/shape).
+// Panics if shape <= 0 or scale <= 0.
+func (r *Random) Gamma(shape, scale float64) float64 {
+	if !(shape > 0) || !(scale > 0) {
+		panic("invalid argument to Gamma")
+	}
+	return r.stdGamma(shape) * scale
+}
+
+// stdGamma returns a gamma distributed float64 with the given shape and scale 1.
+func (r *Random) stdGamma(shape float64) float64 {
+	if shape < 1 {
+		return r.stdGamma(shape+1) * math.Pow(r.float64Open(), 1/shape)
+	}
+
+	d := shape - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := r.StdNormal()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := r.float64Open()
+		x2 := x * x
+		if u < 1-0.0331*x2*x2 || math.Log(u) < 0.5*x2+d*(1-v+math.Log(v)) {
+			return d * v
+		}
+	}
+}
+
+// Beta returns a beta distributed float64 in [0, 1] with shape parameters a and b.
+// It uses the ratio of two gamma variates, or Jöhnk's method in log space when
+// both parameters are below 1, where the gamma variates may underflow.
+// Panics if a <= 0 or b <= 0.
+func (r *Random) Beta(a, b float64) float64 {
+	if !(a > 0) || !(b > 0) {
+		panic("invalid argument to Beta")
+	}
+	if a < 1 && b < 1 {
+		return r.betaJohnk(a, b)
+	}
+	x := r.stdGamma(a)
+	y := r.stdGamma(b)
+	return x / (x + y)
+}
+
+// betaJohnk implements Jöhnk's algorithm: with X = U^(1/a) and Y = V^(1/b),
+// X / (X+Y) given X+Y <= 1 is Beta(a, b) distributed.
+func (r *Random) betaJohnk(a, b float64) float64 {
+	for {
+		logX := math.Log(r.float64Open()) / a
+		logY := math.Log(r.float64Open()) / b
+		logM := math.Max(logX, logY)
+		logSum := logM + math.Log(math.Exp(logX-logM)+math.Exp(logY-logM))
+		if logSum <= 0 {
+			return math.Exp(logX - logSum)
+		}
+	}
+}
+
+// Pareto returns a Pareto distributed float64 with scale (minimum) xm and shape alpha,
+// i.e. P(X > x) = (xm/x)^alpha for x >= xm.
+// Panics if xm <= 0 or alpha <= 0.
+func (r *Random) Pareto(xm, alpha float64) float64 {
+	if !(xm > 0) || !(alpha > 0) {
+		panic("invalid argument to Pareto")
+	}
+	return xm * math.Exp(-math.Log(r.float64Open())/alpha)
+}
+
+// LogNormal returns a float64 whose logarithm is normally distributed with
+// mean mu and standard deviation sigma.
+// Panics if sigma < 0.
+func (r *Random) LogNormal(mu, sigma float64) float64 {
+	if !(sigma >= 0) {
+		panic("invalid argument to LogNormal")
+	}
+	return math.Exp(mu + sigma*r.StdNormal())
+}
+
+// Cauchy returns a Cauchy distributed float64 with location x0 and scale gamma.
+// Panics if gamma <= 0.
+func (r *Random) Cauchy(x0, gamma float64) float64 {
+	if !(gamma > 0) {
+		panic("invalid argument to Cauchy")
+	}
+	return x0 + gamma*math.Tan(math.Pi*(r.float64Open()-0.5))
+}
+
+// Weibull returns a Weibull distributed float64 with shape k and scale lambda,
+// i.e. P(X > x) = exp(-(x/lambda)^k) for x >= 0.
+// Panics if k <= 0 or lambda <= 0.
+func (r *Random) Weibull(k, lambda float64) float64 {
+	if !(k > 0) || !(lambda > 0) {
+		panic("invalid argument to Weibull")
+	}
+	return lambda * math.Pow(-math.Log(r.float64Open()), 1/k)
+}
+
+// Gumbel returns a Gumbel (type I extreme value) distributed float64 with
+// location mu and scale beta.
+// Panics if beta <= 0.
+func (r *Random) Gumbel(mu, beta float64) float64 {
+	if !(beta > 0) {
+		panic("invalid argument to Gumbel")
+	}
+	return mu - beta*math.Log(-math.Log(r.float64Open()))
+}
+
+// Laplace returns a Laplace (double exponential) distributed float64 with
+// location mu and scale b.
+// Panics if b <= 0.
+func (r *Random) Laplace(mu, b float64) float64 {
+	if !(b > 0) {
+		panic("invalid argument to Laplace")
+	}
+	u := r.float64Open()
+	if u < 0.5 {
+		return mu + b*math.Log(2*u)
+	}
+	return mu - b*math.Log(2*(1-u))
+}
+
+// Logistic returns a logistically distributed float64 with location mu and scale s.
+// Panics if s <= 0.
+func (r *Random) Logistic(mu, s float64) float64 {
+	if !(s > 0) {
+		panic("invalid argument to Logistic")
+	}
+	u := r.float64Open()
+	return mu + s*math.Log(u/(1-u))
+}
+
+// Rayleigh returns a Rayleigh distributed float64 with scale sigma.
+// Panics if sigma <= 0.
+func (r *Random) Rayleigh(sigma float64) float64 {
+	if !(sigma > 0) {
+		panic("invalid argument to Rayleigh")
+	}
+	return sigma * math.Sqrt(-2*math.Log(r.float64Open()))
+}
+
+// ChiSquared returns a chi-squared distributed float64 with k degrees of freedom.
+// k need not be an integer.
+// Panics if k <= 0.
+func (r *Random) ChiSquared(k float64) float64 {
+	if !(k > 0) {
+		panic("invalid argument to ChiSquared")
+	}
+	return 2 * r.stdGamma(k/2)
+}
+
+// StudentT returns a float64 that follows Student's t-distribution with nu degrees
+// of freedom, sampled as Z / sqrt(V/nu) for a standard normal Z and a chi-squared V.
+// Panics if nu <= 0.
+func (r *Random) StudentT(nu float64) float64 {
+	if !(nu > 0) {
+		panic("invalid argument to StudentT")
+	}
+	z := r.StdNormal()
+	return z / math.Sqrt(2*r.stdGamma(nu/2)/nu)
+}
+
+// FisherF returns a float64 that follows the F-distribution with d1 and d2 degrees
+// of freedom, sampled as the ratio (V1/d1) / (V2/d2) of two chi-squared variates.
+// Panics if d1 <= 0 or d2 <= 0.
+func (r *Random) FisherF(d1, d2 float64) float64 {
+	if !(d1 > 0) || !(d2 > 0) {
+		panic("invalid argument to FisherF")
+	}
+	x := r.stdGamma(d1/2) / d1
+	y := r.stdGamma(d2/2) / d2
+	return x / y
+}
+
+// Triangular returns a float64 from the triangular distribution on [low, high]
+// with the given mode.
+// Panics unless low <= mode <= high and low < high.
+func (r *Random) Triangular(low, mode, high float64) float64 {
+	if !(low <= mode && mode <= high && low < high) {
+		panic("invalid argument to Triangular")
+	}
+	width := high - low
+	u := r.rand.Float64()
+	if u*width < mode-low {
+		return low + math.Sqrt(u*width*(mode-low))
+	}
+	return high - math.Sqrt((1-u)*width*(high-mode))
+}
@@ -0,0 +1,577 @@
+package random_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/bofry/random"
+	"github.com/bofry/random/mt19937"
+)
+
+// checkMoments draws n values from sample and checks that the sample mean lies within
+// 5 standard errors of mean and that the sample variance lies within relTol of variance.
+func checkMoments(t *testing.T, name string, n int, sample func() float64, mean, variance, relTol float64) {
+	t.Helper()
+	var sum, sumSq float64
+	for i := 0; i < n; i++ {
+		x := sample()
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			t.Fatalf("%s returned %v", name, x)
+		}
+		sum += x
+		sumSq += x * x
+	}
+	m := sum / float64(n)
+	v := sumSq/float64(n) - m*m
+	if tolerance := 5 * math.Sqrt(variance/float64(n)); math.Abs(m-mean) > tolerance {
+		t.Errorf("%s: mean %.5f, expected %.5f ± %.5f", name, m, mean, tolerance)
+	}
+	if math.Abs(v-variance) > relTol*variance {
+		t.Errorf("%s: variance %.5f, expected %.5f ± %.0f%%", name, v, variance, relTol*100)
+	}
+}
+
+// checkPanics tests that every function panics.
+func checkPanics(t *testing.T, functions map[string]func()) {
+	t.Helper()
+	for name, f := range functions {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s did not panic as expected", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+// TestExponential runs Kolmogorov-Smirnov and moment tests on Exponential.
+func TestExponential(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	for _, rate := range []float64{0.5, 1, 20} {
+		rate := rate
+		checkKS(t, "Exponential", 100000, func() float64 { return rng.Exponential(rate) }, func(x float64) float64 {
+			return 1 - math.Exp(-rate*x)
+		})
+		checkMoments(t, "Exponential", 200000, func() float64 { return rng.Exponential(rate) }, 1/rate, 1/(rate*rate), 0.05)
+	}
+
+	checkPanics(t, map[string]func(){
+		"Exponential(0)":   func() { rng.Exponential(0) },
+		"Exponential(-1)":  func() { rng.Exponential(-1) },
+		"Exponential(NaN)": func() { rng.Exponential(math.NaN()) },
+	})
+}
+
+// TestGamma runs Kolmogorov-Smirnov tests on Gamma where the CDF has a closed form,
+// and moment tests across shapes below and above 1.
+func TestGamma(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+
+	// Gamma(3, 2) is an Erlang distribution.
+	checkKS(t, "Gamma(3, 2)", 100000, func() float64 { return rng.Gamma(3, 2) }, func(x float64) float64 {
+		y := x / 2
+		return 1 - math.Exp(-y)*(1+y+y*y/2)
+	})
+	// Gamma(0.5, 2) is a chi-squared distribution with 1 degree of freedom.
+	checkKS(t, "Gamma(0.5, 2)", 100000, func() float64 { return rng.Gamma(0.5, 2) }, func(x float64) float64 {
+		return math.Erf(math.Sqrt(x / 2))
+	})
+
+	for _, tc := range []struct{ shape, scale, relTol float64 }{
+		{0.1, 1, 0.15},
+		{0.7, 3, 0.05},
+		{1, 1, 0.05},
+		{4.5, 0.5, 0.05},
+		{100, 2, 0.05},
+	} {
+		tc := tc
+		checkMoments(t, "Gamma", 200000, func() float64 { return rng.Gamma(tc.shape, tc.scale) },
+			tc.shape*tc.scale, tc.shape*tc.scale*tc.scale, tc.relTol)
+	}
+
+	checkPanics(t, map[string]func(){
+		"Gamma(0, 1)":  func() { rng.Gamma(0, 1) },
+		"Gamma(1, 0)":  func() { rng.Gamma(1, 0) },
+		"Gamma(-1, 1)": func() { rng.Gamma(-1, 1) },
+	})
+}
+
+// TestBeta runs Kolmogorov-Smirnov tests on Beta where the CDF has a closed form,
+// and moment tests across both sampling methods.
+func TestBeta(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+
+	checkKS(t, "Beta(0.5, 0.5)", 100000, func() float64 { return rng.Beta(0.5, 0.5) }, func(x float64) float64 {
+		return 2 / math.Pi * math.Asin(math.Sqrt(x))
+	})
+	checkKS(t, "Beta(2.5, 1)", 100000, func() float64 { return rng.Beta(2.5, 1) }, func(x float64) float64 {
+		return math.Pow(x, 2.5)
+	})
+	checkKS(t, "Beta(1, 3)", 100000, func() float64 { return rng.Beta(1, 3) }, func(x float64) float64 {
+		return 1 - math.Pow(1-x, 3)
+	})
+
+	for _, tc := range []struct{ a, b float64 }{
+		{0.05, 0.05},
+		{0.3, 0.8},
+		{0.5, 4},
+		{2, 5},
+		{30, 10},
+	} {
+		a, b := tc.a, tc.b
+		mean := a / (a + b)
+		variance := a * b / ((a + b) * (a + b) * (a + b + 1))
+		checkMoments(t, "Beta", 200000, func() float64 {
+			x := rng.Beta(a, b)
+			if x < 0 || x > 1 {
+				t.Fatalf("Beta(%v, %v) returned out of range value: %v", a, b, x)
+			}
+			return x
+		}, mean, variance, 0.05)
+	}
+
+	checkPanics(t, map[string]func(){
+		"Beta(0, 1)":   func() { rng.Beta(0, 1) },
+		"Beta(1, 0)":   func() { rng.Beta(1, 0) },
+		"Beta(NaN, 1)": func() { rng.Beta(math.NaN(), 1) },
+	})
+}
+
+// TestPareto runs Kolmogorov-Smirnov tests on Pareto.
+func TestPareto(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	for _, tc := range []struct{ xm, alpha float64 }{
+		{1, 0.5},
+		{2, 1.16},
+		{0.1, 10},
+	} {
+		xm, alpha := tc.xm, tc.alpha
+		checkKS(t, "Pareto", 100000, func() float64 { return rng.Pareto(xm, alpha) }, func(x float64) float64 {
+			if x < xm {
+				return 0
+			}
+			return 1 - math.Pow(xm/x, alpha)
+		})
+	}
+
+	checkPanics(t, map[string]func(){
+		"Pareto(0, 1)":   func() { rng.Pareto(0, 1) },
+		"Pareto(1, 0)":   func() { rng.Pareto(1, 0) },
+		"Pareto(1, NaN)": func() { rng.Pareto(1, math.NaN()) },
+	})
+}
+
+// TestLogNormal runs Kolmogorov-Smirnov tests on LogNormal.
+func TestLogNormal(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	for _, tc := range []struct{ mu, sigma float64 }{
+		{0, 1},
+		{3, 0.25},
+		{-2, 2},
+	} {
+		mu, sigma := tc.mu, tc.sigma
+		cdf := normalCDF(mu, sigma)
+		checkKS(t, "LogNormal", 100000, func() float64 { return rng.LogNormal(mu, sigma) }, func(x float64) float64 {
+			return cdf(math.Log(x))
+		})
+	}
+
+	if x := rng.LogNormal(1, 0); x != math.E {
+		t.Errorf("LogNormal(1, 0) = %v, expected %v", x, math.E)
+	}
+	checkPanics(t, map[string]func(){
+		"LogNormal(0, -1)":  func() { rng.LogNormal(0, -1) },
+		"LogNormal(0, NaN)": func() { rng.LogNormal(0, math.NaN()) },
+	})
+}
+
+// TestCauchy runs Kolmogorov-Smirnov tests on Cauchy.
+func TestCauchy(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	for _, tc := range []struct{ x0, gamma float64 }{
+		{0, 1},
+		{-5, 0.1},
+		{100, 30},
+	} {
+		x0, gamma := tc.x0, tc.gamma
+		checkKS(t, "Cauchy", 100000, func() float64 { return rng.Cauchy(x0, gamma) }, func(x float64) float64 {
+			return 0.5 + math.Atan((x-x0)/gamma)/math.Pi
+		})
+	}
+
+	checkPanics(t, map[string]func(){
+		"Cauchy(0, 0)":  func() { rng.Cauchy(0, 0) },
+		"Cauchy(0, -1)": func() { rng.Cauchy(0, -1) },
+	})
+}
+
+// TestWeibull runs Kolmogorov-Smirnov tests on Weibull.
+func TestWeibull(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	for _, tc := range []struct{ k, lambda float64 }{
+		{0.5, 1},
+		{1.5, 2},
+		{5, 0.3},
+	} {
+		k, lambda := tc.k, tc.lambda
+		checkKS(t, "Weibull", 100000, func() float64 { return rng.Weibull(k, lambda) }, func(x float64) float64 {
+			return 1 - math.Exp(-math.Pow(x/lambda, k))
+		})
+	}
+
+	checkPanics(t, map[string]func(){
+		"Weibull(0, 1)": func() { rng.Weibull(0, 1) },
+		"Weibull(1, 0)": func() { rng.Weibull(1, 0) },
+	})
+}
+
+// TestGumbel runs Kolmogorov-Smirnov tests on Gumbel.
+func TestGumbel(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	for _, tc := range []struct{ mu, beta float64 }{
+		{0, 1},
+		{-3, 0.5},
+		{10, 4},
+	} {
+		mu, beta := tc.mu, tc.beta
+		checkKS(t, "Gumbel", 100000, func() float64 { return rng.Gumbel(mu, beta) }, func(x float64) float64 {
+			return math.Exp(-math.Exp(-(x - mu) / beta))
+		})
+	}
+
+	checkPanics(t, map[string]func(){
+		"Gumbel(0, 0)":   func() { rng.Gumbel(0, 0) },
+		"Gumbel(0, NaN)": func() { rng.Gumbel(0, math.NaN()) },
+	})
+}
+
+// TestLaplace runs Kolmogorov-Smirnov tests on Laplace.
+func TestLaplace(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	for _, tc := range []struct{ mu, b float64 }{
+		{0, 1},
+		{5, 0.2},
+		{-1, 3},
+	} {
+		mu, b := tc.mu, tc.b
+		checkKS(t, "Laplace", 100000, func() float64 { return rng.Laplace(mu, b) }, func(x float64) float64 {
+			if x < mu {
+				return 0.5 * math.Exp((x-mu)/b)
+			}
+			return 1 - 0.5*math.Exp(-(x-mu)/b)
+		})
+	}
+
+	checkPanics(t, map[string]func(){
+		"Laplace(0, 0)":  func() { rng.Laplace(0, 0) },
+		"Laplace(0, -1)": func() { rng.Laplace(0, -1) },
+	})
+}
+
+// TestLogistic runs Kolmogorov-Smirnov tests on Logistic.
+func TestLogistic(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	for _, tc := range []struct{ mu, s float64 }{
+		{0, 1},
+		{2, 0.1},
+		{-7, 5},
+	} {
+		mu, s := tc.mu, tc.s
+		checkKS(t, "Logistic", 100000, func() float64 { return rng.Logistic(mu, s) }, func(x float64) float64 {
+			return 1 / (1 + math.Exp(-(x-mu)/s))
+		})
+	}
+
+	checkPanics(t, map[string]func(){
+		"Logistic(0, 0)":  func() { rng.Logistic(0, 0) },
+		"Logistic(0, -1)": func() { rng.Logistic(0, -1) },
+	})
+}
+
+// TestRayleigh runs Kolmogorov-Smirnov tests on Rayleigh.
+func TestRayleigh(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	for _, sigma := range []float64{0.1, 1, 25} {
+		sigma := sigma
+		checkKS(t, "Rayleigh", 100000, func() float64 { return rng.Rayleigh(sigma) }, func(x float64) float64 {
+			return 1 - math.Exp(-x*x/(2*sigma*sigma))
+		})
+	}
+
+	checkPanics(t, map[string]func(){
+		"Rayleigh(0)":   func() { rng.Rayleigh(0) },
+		"Rayleigh(NaN)": func() { rng.Rayleigh(math.NaN()) },
+	})
+}
+
+// TestChiSquared runs Kolmogorov-Smirnov tests on ChiSquared where the CDF has a
+// closed form, and moment tests including non-integer degrees of freedom.
+func TestChiSquared(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	checkKS(t, "ChiSquared(1)", 100000, func() float64 { return rng.ChiSquared(1) }, func(x float64) float64 {
+		return math.Erf(math.Sqrt(x / 2))
+	})
+	checkKS(t, "ChiSquared(2)", 100000, func() float64 { return rng.ChiSquared(2) }, func(x float64) float64 {
+		return 1 - math.Exp(-x/2)
+	})
+	for _, k := range []float64{0.5, 3.7, 50} {
+		k := k
+		checkMoments(t, "ChiSquared", 200000, func() float64 { return rng.ChiSquared(k) }, k, 2*k, 0.05)
+	}
+
+	checkPanics(t, map[string]func(){
+		"ChiSquared(0)":   func() { rng.ChiSquared(0) },
+		"ChiSquared(NaN)": func() { rng.ChiSquared(math.NaN()) },
+	})
+}
+
+// TestStudentT runs Kolmogorov-Smirnov tests on StudentT where the CDF has a
+// closed form, and a moment test with finite kurtosis.
+func TestStudentT(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	checkKS(t, "StudentT(1)", 100000, func() float64 { return rng.StudentT(1) }, func(x float64) float64 {
+		return 0.5 + math.Atan(x)/math.Pi
+	})
+	checkKS(t, "StudentT(2)", 100000, func() float64 { return rng.StudentT(2) }, func(x float64) float64 {
+		return 0.5 + x/(2*math.Sqrt(2+x*x))
+	})
+	checkMoments(t, "StudentT(10)", 200000, func() float64 { return rng.StudentT(10) }, 0, 1.25, 0.05)
+
+	checkPanics(t, map[string]func(){
+		"StudentT(0)":  func() { rng.StudentT(0) },
+		"StudentT(-1)": func() { rng.StudentT(-1) },
+	})
+}
+
+// TestFisherF runs Kolmogorov-Smirnov tests on FisherF where the CDF has a
+// closed form, and a moment test with finite kurtosis.
+func TestFisherF(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	for _, d2 := range []float64{2, 5, 30} {
+		d2 := d2
+		checkKS(t, "FisherF(2, d2)", 100000, func() float64 { return rng.FisherF(2, d2) }, func(x float64) float64 {
+			return 1 - math.Pow(1+2*x/d2, -d2/2)
+		})
+	}
+	// mean d2/(d2-2), variance 2*d2²*(d1+d2-2) / (d1*(d2-2)²*(d2-4))
+	checkMoments(t, "FisherF(5, 20)", 200000, func() float64 { return rng.FisherF(5, 20) },
+		20.0/18, 2*400*23/(5*324*16.0), 0.1)
+
+	checkPanics(t, map[string]func(){
+		"FisherF(0, 1)": func() { rng.FisherF(0, 1) },
+		"FisherF(1, 0)": func() { rng.FisherF(1, 0) },
+	})
+}
+
+// TestContinuousReproducible tests that equally seeded MT19937 sources, and a reseeded
+// one, give the same sequence for the distributions built on the Gamma/Normal core.
+func TestContinuousReproducible(t *testing.T) {
+	a, b := mt19937.New(), mt19937.New()
+	a.Seed(seed)
+	b.Seed(seed)
+	ra, rb := random.New(a), random.New(b)
+	draw := func(r *random.Random) []float64 {
+		return []float64{r.ChiSquared(3), r.StudentT(4.5), r.FisherF(2, 7), r.Gamma(0.3, 1), r.Beta(0.5, 0.5)}
+	}
+
+	var first [][]float64
+	for i := 0; i < 1000; i++ {
+		x, y := draw(ra), draw(rb)
+		if !isEqual(x, y) {
+			t.Fatalf("draw %d differs: %v and %v", i, x, y)
+		}
+		first = append(first, x)
+	}
+
+	a.Seed(seed)
+	for i := 0; i < 1000; i++ {
+		if x := draw(ra); !isEqual(x, first[i]) {
+			t.Fatalf("draw %d after reseeding differs: %v and %v", i, x, first[i])
+		}
+	}
+}
+
+// TestTriangular runs Kolmogorov-Smirnov tests on Triangular, including modes at the bounds.
+func TestTriangular(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	for _, tc := range []struct{ low, mode, high float64 }{
+		{0, 0.5, 1},
+		{-2, 3, 10},
+		{0, 0, 1},
+		{0, 1, 1},
+	} {
+		low, mode, high := tc.low, tc.mode, tc.high
+		checkKS(t, "Triangular", 100000, func() float64 {
+			x := rng.Triangular(low, mode, high)
+			if x < low || x > high {
+				t.Fatalf("Triangular(%v, %v, %v) returned out of range value: %v", low, mode, high, x)
+			}
+			return x
+		}, func(x float64) float64 {
+			if x < mode {
+				return (x - low) * (x - low) / ((high - low) * (mode - low))
+			}
+			return 1 - (high-x)*(high-x)/((high-low)*(high-mode))
+		})
+	}
+
+	checkPanics(t, map[string]func(){
+		"Triangular(1, 1, 1)":   func() { rng.Triangular(1, 1, 1) },
+		"Triangular(0, 2, 1)":   func() { rng.Triangular(0, 2, 1) },
+		"Triangular(0, -1, 1)":  func() { rng.Triangular(0, -1, 1) },
+		"Triangular(0, NaN, 1)": func() { rng.Triangular(0, math.NaN(), 1) },
+	})
+}
+
+// sequenceSource is a rand.Source that cycles through a fixed sequence of values.
+type sequenceSource struct {
+	values []int64
+	i      int
+}
+
+func (s *sequenceSource) Int63() int64 {
+	v := s.values[s.i%len(s.values)]
+	s.i++
+	return v
+}
+
+func (s *sequenceSource) Seed(int64) {}
+
+// TestInverseCDFExtremes tests that the inverse-CDF samplers stay finite when the
+// source yields 0 or the values closest to 0 and 1.
+func TestInverseCDFExtremes(t *testing.T) {
+	rng := random.New(&sequenceSource{values: []int64{0, 1, 0, 1 << 62, 1<<63 - 1, 1<<63 - 1024}})
+	samplers := map[string]func() float64{
+		"Exponential": func() float64 { return rng.Exponential(1) },
+		"Pareto":      func() float64 { return rng.Pareto(1, 1) },
+		"Cauchy":      func() float64 { return rng.Cauchy(0, 1) },
+		"Weibull":     func() float64 { return rng.Weibull(0.5, 1) },
+		"Gumbel":      func() float64 { return rng.Gumbel(0, 1) },
+		"Laplace":     func() float64 { return rng.Laplace(0, 1) },
+		"Logistic":    func() float64 { return rng.Logistic(0, 1) },
+		"Rayleigh":    func() float64 { return rng.Rayleigh(1) },
+	}
+	for name, sample := range samplers {
+		for i := 0; i < 20; i++ {
+			if x := sample(); math.IsNaN(x) || math.IsInf(x, 0) {
+				t.Errorf("%s returned %v", name, x)
+			}
+		}
+	}
+}
+
+// TestContinuousSafeRandom tests the SafeRandom counterparts.
+func TestContinuousSafeRandom(t *testing.T) {
+	safe := random.NewSafeRandom(rand.NewSource(seed))
+	checkMoments(t, "SafeRandom.Exponential", 100000, func() float64 { return safe.Exponential(2) }, 0.5, 0.25, 0.05)
+	checkMoments(t, "SafeRandom.Gamma", 100000, func() float64 { return safe.Gamma(2, 3) }, 6, 18, 0.05)
+	checkMoments(t, "SafeRandom.Beta", 100000, func() float64 { return safe.Beta(2, 2) }, 0.5, 0.05, 0.05)
+	checkMoments(t, "SafeRandom.Pareto", 100000, func() float64 { return safe.Pareto(1, 10) }, 10.0/9, 10.0/(81*8), 0.1)
+	checkMoments(t, "SafeRandom.LogNormal", 100000, func() float64 { return safe.LogNormal(0, 0.5) },
+		math.Exp(0.125), (math.Exp(0.25)-1)*math.Exp(0.25), 0.05)
+	checkMoments(t, "SafeRandom.Weibull", 100000, func() float64 { return safe.Weibull(1, 2) }, 2, 4, 0.05)
+	checkMoments(t, "SafeRandom.Gumbel", 100000, func() float64 { return safe.Gumbel(0, 1) }, 0.5772156649015329, math.Pi*math.Pi/6, 0.05)
+	checkMoments(t, "SafeRandom.Laplace", 100000, func() float64 { return safe.Laplace(1, 2) }, 1, 8, 0.05)
+	checkMoments(t, "SafeRandom.Logistic", 100000, func() float64 { return safe.Logistic(1, 2) }, 1, 4*math.Pi*math.Pi/3, 0.05)
+	checkMoments(t, "SafeRandom.Rayleigh", 100000, func() float64 { return safe.Rayleigh(1) }, math.Sqrt(math.Pi/2), 2-math.Pi/2, 0.05)
+	checkMoments(t, "SafeRandom.ChiSquared", 100000, func() float64 { return safe.ChiSquared(4) }, 4, 8, 0.05)
+	checkMoments(t, "SafeRandom.StudentT", 100000, func() float64 { return safe.StudentT(12) }, 0, 1.2, 0.05)
+	checkMoments(t, "SafeRandom.FisherF", 100000, func() float64 { return safe.FisherF(5, 20) }, 20.0/18, 2*400*23/(5*324*16.0), 0.1)
+	checkMoments(t, "SafeRandom.Triangular", 100000, func() float64 { return safe.Triangular(0, 1, 4) }, 5.0/3, 13.0/18, 0.05)
+	checkKS(t, "SafeRandom.Cauchy", 100000, func() float64 { return safe.Cauchy(0, 1) }, func(x float64) float64 {
+		return 0.5 + math.Atan(x)/math.Pi
+	})
+}
+
+func Benchmark_Exponential(b *testing.B) {
+	for n := b.N; n > 0; n-- {
+		rng.Exponential(1)
+	}
+}
+
+func Benchmark_Gamma(b *testing.B) {
+	for n := b.N; n > 0; n-- {
+		rng.Gamma(2.5, 1)
+	}
+}
+
+func Benchmark_Beta(b *testing.B) {
+	for n := b.N; n > 0; n-- {
+		rng.Beta(2, 5)
+	}
+}
+
+func Benchmark_Pareto(b *testing.B) {
+	for n := b.N; n > 0; n-- {
+		rng.Pareto(1, 1.16)
+	}
+}
+
+func Benchmark_LogNormal(b *testing.B) {
+	for n := b.N; n > 0; n-- {
+		rng.LogNormal(0, 1)
+	}
+}
+
+func Benchmark_Cauchy(b *testing.B) {
+	for n := b.N; n > 0; n-- {
+		rng.Cauchy(0, 1)
+	}
+}
+
+func Benchmark_Weibull(b *testing.B) {
+	for n := b.N; n > 0; n-- {
+		rng.Weibull(1.5, 1)
+	}
+}
+
+func Benchmark_Gumbel(b *testing.B) {
+	for n := b.N; n > 0; n-- {
+		rng.Gumbel(0, 1)
+	}
+}
+
+func Benchmark_Laplace(b *testing.B) {
+	for n := b.N; n > 0; n-- {
+		rng.Laplace(0, 1)
+	}
+}
+
+func Benchmark_Logistic(b *testing.B) {
+	for n := b.N; n > 0; n-- {
+		rng.Logistic(0, 1)
+	}
+}
+
+func Benchmark_Rayleigh(b *testing.B) {
+	for n := b.N; n > 0; n-- {
+		rng.Rayleigh(1)
+	}
+}
+
+func Benchmark_ChiSquared(b *testing.B) {
+	for n := b.N; n > 0; n-- {
+		rng.ChiSquared(5)
+	}
+}
+
+func Benchmark_StudentT(b *testing.B) {
+	for n := b.N; n > 0; n-- {
+		rng.StudentT(5)
+	}
+}
+
+func Benchmark_FisherF(b *testing.B) {
+	for n := b.N; n > 0; n-- {
+		rng.FisherF(5, 10)
+	}
+}
+
+func Benchmark_Triangular(b *testing.B) {
+	for n := b.N; n > 0; n-- {
+		rng.Triangular(0, 0.3, 1)
+	}
+}
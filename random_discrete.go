@@ -0,0 +1,396 @@
+package random
+
+import "math"
+
+// Poisson returns a Poisson distributed int with mean lambda.
+// It uses inversion by sequential search for lambda < 10 and Hörmann's transformed
+// rejection with squeeze (PTRS) otherwise, so the cost is O(1) for large lambda.
+// Results that do not fit in an int are clamped to math.MaxInt.
+// Panics if lambda < 0 or lambda is not finite.
+func (r *Random) Poisson(lambda float64) int {
+	if !(lambda >= 0) || math.IsInf(lambda, 1) {
+		panic("invalid argument to Poisson")
+	}
+	if lambda < 10 {
+		return r.poissonInversion(lambda)
+	}
+	return r.poissonPTRS(lambda)
+}
+
+// poissonInversion samples Poisson(lambda) by inverting the CDF.
+func (r *Random) poissonInversion(lambda float64) int {
+	p := math.Exp(-lambda)
+	cdf := p
+	u := r.rand.Float64()
+	k := 0
+	for u > cdf {
+		k++
+		p *= lambda / float64(k)
+		cdf += p
+		if p == 0 { // only reached through rounding of the CDF close to 1
+			break
+		}
+	}
+	return k
+}
+
+// poissonPTRS samples Poisson(lambda) for lambda >= 10 with the PTRS algorithm
+// (W. Hörmann, "The transformed rejection method for generating Poisson random
+// variables", 1993).
+func (r *Random) poissonPTRS(lambda float64) int {
+	logLambda := math.Log(lambda)
+	b := 0.931 + 2.53*math.Sqrt(lambda)
+	a := -0.059 + 0.02483*b
+	invAlpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+
+	for {
+		u := r.rand.Float64() - 0.5
+		v := r.float64Open()
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + lambda + 0.43)
+		if us >= 0.07 && v <= vr {
+			return clampInt(k)
+		}
+		if k < 0 || (us < 0.013 && v > us) {
+			continue
+		}
+		if math.Log(v)+math.Log(invAlpha)-math.Log(a/(us*us)+b) <= poissonLogPMF(k, lambda, logLambda) {
+			return clampInt(k)
+		}
+	}
+}
+
+// poissonLogPMF returns log(lambda^k * e^-lambda / k!). For large k it expands log(k!)
+// with stirlingTail and writes k*log(lambda/k) - (lambda-k) with log1p, since the
+// direct terms are of order lambda*log(lambda) and cancel to a value of order 1.
+func poissonLogPMF(k, lambda, logLambda float64) float64 {
+	if k < 10 {
+		lg, _ := math.Lgamma(k + 1)
+		return -lambda + k*logLambda - lg
+	}
+	d := lambda - k
+	return k*math.Log1p(d/k) - d - 0.5*math.Log(2*math.Pi*k) -
+		((k+0.5)*math.Log1p(1/k) - 1) - stirlingTail(k)
+}
+
+// Binomial returns the number of successes in n independent trials with success
+// probability p. It uses inversion when n*min(p, 1-p) < 10 and Hörmann's
+// transformed rejection with squeeze (BTRS) otherwise, so the cost is O(1) for large n.
+// Panics if n < 0 or p is not in [0, 1].
+func (r *Random) Binomial(n int, p float64) int {
+	if n < 0 || !(p >= 0 && p <= 1) {
+		panic("invalid argument to Binomial")
+	}
+	if p > 0.5 {
+		return n - r.binomial(n, 1-p)
+	}
+	return r.binomial(n, p)
+}
+
+// binomial samples Binomial(n, p) for p <= 0.5.
+func (r *Random) binomial(n int, p float64) int {
+	if n == 0 || p == 0 {
+		return 0
+	}
+	if float64(n)*p < 10 {
+		return r.binomialInversion(n, p)
+	}
+	return r.binomialBTRS(n, p)
+}
+
+// binomialInversion samples Binomial(n, p) by inverting the CDF, starting at 0.
+// Its expected cost is O(n*p).
+func (r *Random) binomialInversion(n int, p float64) int {
+	s := p / (1 - p)
+	a := float64(n+1) * s
+	f := math.Exp(float64(n) * math.Log1p(-p)) // (1-p)^n without rounding 1-p for tiny p
+	u := r.rand.Float64()
+	k := 0
+	for u > f && k < n {
+		u -= f
+		k++
+		f *= a/float64(k) - s
+	}
+	return k
+}
+
+// binomialBTRS samples Binomial(n, p) for p <= 0.5 and n*p >= 10 with the BTRS
+// algorithm (W. Hörmann, "The generation of binomial random variates", 1993).
+func (r *Random) binomialBTRS(n int, p float64) int {
+	nf := float64(n)
+	stddev := math.Sqrt(nf * p * (1 - p))
+	b := 1.15 + 2.53*stddev
+	a := -0.0873 + 0.0248*b + 0.01*p
+	c := nf*p + 0.5
+	vr := 0.92 - 4.2/b
+	odds := p / (1 - p)
+	alpha := (2.83 + 5.1/b) * stddev
+	m := math.Floor((nf + 1) * p)
+
+	for {
+		u := r.rand.Float64() - 0.5
+		v := r.float64Open()
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + c)
+		if us >= 0.07 && v <= vr {
+			return int(k)
+		}
+		if k < 0 || k > nf {
+			continue
+		}
+		v = math.Log(v * alpha / (a/(us*us) + b))
+		bound := (m+0.5)*math.Log((m+1)/(odds*(nf-m+1))) +
+			(nf+1)*math.Log1p((k-m)/(nf-k+1)) +
+			(k+0.5)*math.Log(odds*(nf-k+1)/(k+1)) +
+			stirlingTail(m) + stirlingTail(nf-m) - stirlingTail(k) - stirlingTail(nf-k)
+		if v <= bound {
+			return int(k)
+		}
+	}
+}
+
+// stirlingTail returns log(k!) - [(k+0.5)log(k+1) - (k+1) + log(2π)/2], the error of
+// Stirling's approximation, for a non-negative integer k.
+func stirlingTail(k float64) float64 {
+	if k < 10 {
+		lg, _ := math.Lgamma(k + 1)
+		return lg - (k+0.5)*math.Log(k+1) + (k + 1) - 0.5*math.Log(2*math.Pi)
+	}
+	kp1sq := (k + 1) * (k + 1)
+	return (1.0/12 - (1.0/360-1.0/1260/kp1sq)/kp1sq) / (k + 1)
+}
+
+// Geometric returns the number of failures before the first success in independent
+// trials with success probability p, so the number of trials is Geometric(p)+1.
+// It uses inversion, so the cost is O(1) for any p. Results that do not fit in an int
+// are clamped to math.MaxInt.
+// Panics if p is not in (0, 1].
+func (r *Random) Geometric(p float64) int {
+	if !(p > 0 && p <= 1) {
+		panic("invalid argument to Geometric")
+	}
+	if p == 1 {
+		return 0
+	}
+	return clampInt(math.Floor(math.Log(r.float64Open()) / math.Log1p(-p)))
+}
+
+// NegativeBinomial returns the number of failures before the n-th success in
+// independent trials with success probability p. n need not be an integer.
+// It samples a Poisson distribution whose mean is gamma distributed, so the cost
+// is O(1) for large n. Results that do not fit in an int are clamped to math.MaxInt.
+// Panics if n <= 0 or p is not in (0, 1].
+func (r *Random) NegativeBinomial(n, p float64) int {
+	if !(n > 0) || math.IsInf(n, 1) || !(p > 0 && p <= 1) {
+		panic("invalid argument to NegativeBinomial")
+	}
+	if p == 1 {
+		return 0
+	}
+	lambda := r.stdGamma(n) * (1 - p) / p
+	if lambda >= math.MaxInt64 {
+		return math.MaxInt
+	}
+	return r.Poisson(lambda)
+}
+
+// Hypergeometric returns the number of successes in draws items drawn without
+// replacement from a population of total items, of which successes are successes.
+// It uses inversion when the result is bounded by a small number and Stadlober's
+// ratio-of-uniforms method (HRUA) otherwise, so the cost is O(1) for large parameters.
+// Panics unless 0 <= successes <= total and 0 <= draws <= total.
+func (r *Random) Hypergeometric(total, successes, draws int) int {
+	if successes < 0 || successes > total || draws < 0 || draws > total {
+		panic("invalid argument to Hypergeometric")
+	}
+
+	// By symmetry, sample the smaller of the two kinds of item from the smaller of
+	// the drawn and the remaining items, then map the result back.
+	m := minInt(draws, total-draws)
+	good := minInt(successes, total-successes)
+	var k int
+	if minInt(m, good) < 10 {
+		k = r.hypergeometricInversion(total, good, m)
+	} else {
+		k = r.hypergeometricHRUA(total, good, m)
+	}
+	if successes > total-successes {
+		k = m - k
+	}
+	if m < draws {
+		k = successes - k
+	}
+	return k
+}
+
+// hypergeometricInversion samples Hypergeometric(total, good, m) for good, m <= total/2
+// by inverting the CDF, starting at 0. Its cost is O(min(good, m)).
+func (r *Random) hypergeometricInversion(total, good, m int) int {
+	bad := total - good
+	// f = P(0) = C(bad, m) / C(total, m), as a product of fewer than 10 ratios: the
+	// log-factorials of huge totals would cancel to noise.
+	hi := minInt(good, m)
+	f := 1.0
+	for i := 0; i < hi; i++ {
+		f *= float64(total-good-m+hi-i) / float64(total-i)
+	}
+	u := r.rand.Float64()
+	k := 0
+	for u > f && k < hi {
+		u -= f
+		f *= float64(good-k) * float64(m-k) / (float64(k+1) * float64(bad-m+k+1))
+		k++
+	}
+	return k
+}
+
+// hypergeometricHRUA samples Hypergeometric(total, good, m) for good, m <= total/2
+// with the HRUA algorithm (E. Stadlober, "Sampling from Poisson, binomial and
+// hypergeometric distributions: ratio of uniforms as a simple and fast alternative", 1989).
+func (r *Random) hypergeometricHRUA(total, good, m int) int {
+	const (
+		d1 = 1.7155277699214135 // 2*sqrt(2/e)
+		d2 = 0.8989161620588988 // 3 - 2*sqrt(3/e)
+	)
+	bad := total - good
+	p := float64(good) / float64(total)
+	a := float64(m)*p + 0.5
+	c := math.Sqrt(float64(total-m)*float64(m)*p*(1-p)/float64(total-1) + 0.5)
+	h := d1*c + d2
+	mode := int(float64(m+1) * float64(good+1) / float64(total+2))
+	// Values more than 16 standard deviations above the mean have a negligible probability.
+	b := math.Min(float64(minInt(m, good)+1), math.Floor(a+16*c))
+
+	for {
+		u := r.float64Open()
+		v := r.rand.Float64()
+		x := a + h*(v-0.5)/u
+		if x < 0 || x >= b {
+			continue
+		}
+		k := int(x)
+		// t = log(P(k) / P(mode))
+		t := logFactorialRatio(mode, k) + logFactorialRatio(good-mode, good-k) +
+			logFactorialRatio(m-mode, m-k) + logFactorialRatio(bad-m+mode, bad-m+k)
+		if u*(4-u)-3 <= t {
+			return k
+		}
+		if u*(u-t) >= 1 {
+			continue
+		}
+		if 2*math.Log(u) <= t {
+			return k
+		}
+	}
+}
+
+// logFactorial returns log(k!).
+func logFactorial(k int) float64 {
+	lg, _ := math.Lgamma(float64(k) + 1)
+	return lg
+}
+
+// logFactorialRatio returns log(a! / b!). For large a and b it expands both
+// log-factorials with stirlingTail and subtracts them analytically, since each is of
+// order a*log(a) and their difference can be small.
+func logFactorialRatio(a, b int) float64 {
+	if a < 10 || b < 10 {
+		return logFactorial(a) - logFactorial(b)
+	}
+	af, bf := float64(a), float64(b)
+	d := float64(a - b)
+	return (bf+0.5)*math.Log1p(d/(bf+1)) + d*math.Log(af+1) - d + stirlingTail(af) - stirlingTail(bf)
+}
+
+// clampInt converts a non-negative integral float64 to int, clamping it to math.MaxInt.
+func clampInt(x float64) int {
+	if x >= math.MaxInt64 {
+		return math.MaxInt
+	}
+	return int(x)
+}
+
+// minInt returns the smaller of a and b.
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Zipf returns a Zipf distributed uint64 in [0, imax], where the probability of k
+// is proportional to (v+k)^(-s). It uses rejection-inversion (W. Hörmann and
+// G. Derflinger, "Rejection-inversion to generate variates from monotone discrete
+// distributions", 1996), so the cost is O(1), and yields the same values as
+// math/rand.Zipf over the same source.
+// Panics if s <= 1 or v < 1.
+func (r *Random) Zipf(s, v float64, imax uint64) uint64 {
+	if !(s > 1) || !(v >= 1) {
+		panic("invalid argument to Zipf")
+	}
+
+	oneMinusS := 1 - s
+	h := func(x float64) float64 { return math.Exp(oneMinusS*math.Log(v+x)) / oneMinusS }
+	hInv := func(x float64) float64 { return math.Exp(math.Log(oneMinusS*x)/oneMinusS) - v }
+	hxm := h(float64(imax) + 0.5)
+	hx0MinusHxm := h(0.5) - math.Exp(-s*math.Log(v)) - hxm
+	squeeze := 1 - hInv(h(1.5)-math.Exp(-s*math.Log(v+1)))
+
+	for {
+		u := hxm + r.rand.Float64()*hx0MinusHxm
+		x := hInv(u)
+		k := math.Floor(x + 0.5)
+		if k-x <= squeeze || u >= h(k+0.5)-math.Exp(-s*math.Log(k+v)) {
+			return uint64(k)
+		}
+	}
+}
+
+// Multinomial distributes n trials across categories with probability proportional
+// to the weights in probs and stores the count of each category in out. The counts
+// are distributed exactly like those of n successive Float64w(probs) draws, but are
+// computed with one conditional Binomial per category, in O(len(probs)) time.
+// Zero weights never receive a count. out must have the same length as probs.
+// Panics if probs is invalid as for Float64w, if len(out) != len(probs), or if n < 0.
+func (r *Random) Multinomial(n int, probs []float64, out []int) {
+	if err := r.TryMultinomial(n, probs, out); err != nil {
+		panic(err)
+	}
+}
+
+// TryMultinomial is like Multinomial but returns an error instead of panicking.
+func (r *Random) TryMultinomial(n int, probs []float64, out []int) error {
+	total, err := sumWeights(probs)
+	if err != nil {
+		return err
+	}
+	if len(out) != len(probs) {
+		return ErrLengthMismatch
+	}
+	if n < 0 {
+		return ErrSampleSize
+	}
+
+	last := len(probs) - 1
+	for probs[last] == 0 {
+		last--
+	}
+	// The count of category i, given the counts before it, is binomial with the
+	// trials left and the weight of i relative to the weight left. The last positive
+	// category takes all remaining trials, so rounding in rest cannot lose any.
+	remaining, rest := n, total
+	for i, w := range probs {
+		switch {
+		case i == last:
+			out[i] = remaining
+		case i > last || remaining == 0 || w == 0:
+			out[i] = 0
+		default:
+			out[i] = r.Binomial(remaining, math.Min(w/rest, 1))
+		}
+		remaining -= out[i]
+		rest -= w
+	}
+	return nil
+}
@@ -0,0 +1,549 @@
+package random_test
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/bofry/random"
+)
+
+// checkPMF runs a chi-square goodness-of-fit test of n draws from sample against pmf.
+// Every k in [lo, hi] with an expected count of at least 5 gets its own bin; all other
+// values, including those outside [lo, hi], are pooled into one remainder bin.
+func checkPMF(t *testing.T, name string, n int, sample func() int, pmf func(int) float64, lo, hi int) {
+	t.Helper()
+	bins := make(map[int]int) // k -> index into counts and weights, in increasing k
+	var weights []float64
+	rest := float64(n)
+	for k := lo; k <= hi; k++ {
+		if e := float64(n) * pmf(k); e >= 5 {
+			bins[k] = len(weights)
+			weights = append(weights, e)
+			rest -= e
+		}
+	}
+
+	counts := make([]int, len(weights), len(weights)+1)
+	var restObserved int
+	for i := 0; i < n; i++ {
+		if bin, ok := bins[sample()]; ok {
+			counts[bin]++
+		} else {
+			restObserved++
+		}
+	}
+
+	if rest >= 5 {
+		counts = append(counts, restObserved)
+		weights = append(weights, rest)
+	} else if restObserved > 5+int(10*rest) {
+		t.Errorf("%s: %d draws outside the expected bins, expected %.2f", name, restObserved, rest)
+	}
+	checkChiSquare(t, name, counts, weights)
+}
+
+// poissonPMF returns the probability mass function of Poisson(lambda).
+func poissonPMF(lambda float64) func(int) float64 {
+	return func(k int) float64 {
+		lg, _ := math.Lgamma(float64(k) + 1)
+		return math.Exp(float64(k)*math.Log(lambda) - lambda - lg)
+	}
+}
+
+// binomialPMF returns the probability mass function of Binomial(n, p).
+func binomialPMF(n int, p float64) func(int) float64 {
+	return func(k int) float64 {
+		if k < 0 || k > n {
+			return 0
+		}
+		return math.Exp(logChoose(n, k) + float64(k)*math.Log(p) + float64(n-k)*math.Log1p(-p))
+	}
+}
+
+// logChoose returns log(n choose k). Small k are summed term by term, as the
+// difference of log-gammas loses all precision for huge n.
+func logChoose(n, k int) float64 {
+	if k > n-k {
+		k = n - k
+	}
+	if k < 1000 {
+		lg, _ := math.Lgamma(float64(k) + 1)
+		var sum float64
+		for i := 0; i < k; i++ {
+			sum += math.Log(float64(n - i))
+		}
+		return sum - lg
+	}
+	a, _ := math.Lgamma(float64(n) + 1)
+	b, _ := math.Lgamma(float64(k) + 1)
+	c, _ := math.Lgamma(float64(n-k) + 1)
+	return a - b - c
+}
+
+// TestPoisson runs chi-square tests on Poisson for both the inversion and
+// the rejection method, including a large mean.
+func TestPoisson(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	for _, lambda := range []float64{0.01, 1, 4.5, 9.99, 10, 37.5, 1000, 1e7} {
+		lambda := lambda
+		width := int(10*math.Sqrt(lambda)) + 10
+		checkPMF(t, "Poisson", 200000, func() int { return rng.Poisson(lambda) }, poissonPMF(lambda),
+			int(lambda)-width, int(lambda)+width)
+	}
+
+	if k := rng.Poisson(0); k != 0 {
+		t.Errorf("Poisson(0) = %d, expected 0", k)
+	}
+	// The PMF is not needed to check a huge mean: shifted draws must have variance lambda.
+	checkMoments(t, "Poisson(1e17)", 200000, func() float64 { return float64(rng.Poisson(1e17)) - 1e17 }, 0, 1e17, 0.02)
+	if k := rng.Poisson(1e19); k != math.MaxInt {
+		t.Errorf("Poisson(1e19) = %d, expected math.MaxInt", k)
+	}
+	checkPanics(t, map[string]func(){
+		"Poisson(-1)":   func() { rng.Poisson(-1) },
+		"Poisson(NaN)":  func() { rng.Poisson(math.NaN()) },
+		"Poisson(+Inf)": func() { rng.Poisson(math.Inf(1)) },
+	})
+}
+
+// TestBinomial runs chi-square tests on Binomial for both the inversion and
+// the rejection method, including p > 0.5 and a large number of trials.
+func TestBinomial(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	for _, tc := range []struct {
+		n int
+		p float64
+	}{
+		{1, 0.5},
+		{20, 0.3},
+		{1000, 0.001},
+		{100, 0.1},
+		{100, 0.5},
+		{500, 0.93},
+		{1e9, 0.3},
+		{1e9, 1e-6},
+		{1 << 60, 5.0 / (1 << 60)},
+		{1e17, 5e-17},
+		{1 << 60, 2e-17},
+		{1 << 62, 1e-16},
+	} {
+		n, p := tc.n, tc.p
+		mean := float64(n) * p
+		width := int(10*math.Sqrt(mean*(1-p))) + 10
+		checkPMF(t, "Binomial", 200000, func() int {
+			k := rng.Binomial(n, p)
+			if k < 0 || k > n {
+				t.Fatalf("Binomial(%d, %v) returned out of range value: %d", n, p, k)
+			}
+			return k
+		}, binomialPMF(n, p), int(mean)-width, int(mean)+width)
+	}
+
+	for _, tc := range []struct {
+		n, expected int
+		p           float64
+	}{
+		{0, 0, 0.5},
+		{10, 0, 0},
+		{10, 10, 1},
+	} {
+		if k := rng.Binomial(tc.n, tc.p); k != tc.expected {
+			t.Errorf("Binomial(%d, %v) = %d, expected %d", tc.n, tc.p, k, tc.expected)
+		}
+	}
+	checkPanics(t, map[string]func(){
+		"Binomial(-1, 0.5)":  func() { rng.Binomial(-1, 0.5) },
+		"Binomial(10, -0.1)": func() { rng.Binomial(10, -0.1) },
+		"Binomial(10, 1.1)":  func() { rng.Binomial(10, 1.1) },
+		"Binomial(10, NaN)":  func() { rng.Binomial(10, math.NaN()) },
+	})
+}
+
+// TestGeometric runs chi-square tests on Geometric, including a small p.
+func TestGeometric(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	for _, p := range []float64{0.9, 0.5, 0.1, 1e-4} {
+		p := p
+		pmf := func(k int) float64 {
+			if k < 0 {
+				return 0
+			}
+			return p * math.Exp(float64(k)*math.Log1p(-p))
+		}
+		checkPMF(t, "Geometric", 200000, func() int { return rng.Geometric(p) }, pmf, 0, int(30/p))
+	}
+
+	if k := rng.Geometric(1); k != 0 {
+		t.Errorf("Geometric(1) = %d, expected 0", k)
+	}
+	if k := rng.Geometric(math.SmallestNonzeroFloat64); k < 0 {
+		t.Errorf("Geometric(%v) = %d, expected a non-negative value", math.SmallestNonzeroFloat64, k)
+	}
+	checkPanics(t, map[string]func(){
+		"Geometric(0)":   func() { rng.Geometric(0) },
+		"Geometric(1.5)": func() { rng.Geometric(1.5) },
+		"Geometric(NaN)": func() { rng.Geometric(math.NaN()) },
+	})
+}
+
+// TestNegativeBinomial runs chi-square tests on NegativeBinomial, including
+// a non-integer and a large number of successes.
+func TestNegativeBinomial(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	for _, tc := range []struct{ n, p float64 }{
+		{1, 0.3},
+		{2.5, 0.5},
+		{10, 0.05},
+		{1e6, 0.3},
+	} {
+		n, p := tc.n, tc.p
+		pmf := func(k int) float64 {
+			if k < 0 {
+				return 0
+			}
+			a, _ := math.Lgamma(float64(k) + n)
+			b, _ := math.Lgamma(n)
+			c, _ := math.Lgamma(float64(k) + 1)
+			return math.Exp(a - b - c + n*math.Log(p) + float64(k)*math.Log1p(-p))
+		}
+		mean := n * (1 - p) / p
+		width := int(10*math.Sqrt(mean/p)) + 10
+		checkPMF(t, "NegativeBinomial", 200000, func() int { return rng.NegativeBinomial(n, p) }, pmf,
+			int(mean)-width, int(mean)+width)
+	}
+
+	if k := rng.NegativeBinomial(3, 1); k != 0 {
+		t.Errorf("NegativeBinomial(3, 1) = %d, expected 0", k)
+	}
+	checkPanics(t, map[string]func(){
+		"NegativeBinomial(0, 0.5)":    func() { rng.NegativeBinomial(0, 0.5) },
+		"NegativeBinomial(+Inf, 0.5)": func() { rng.NegativeBinomial(math.Inf(1), 0.5) },
+		"NegativeBinomial(1, 0)":      func() { rng.NegativeBinomial(1, 0) },
+		"NegativeBinomial(1, 2)":      func() { rng.NegativeBinomial(1, 2) },
+	})
+}
+
+// hypergeometricPMF returns the probability mass function of Hypergeometric(total,
+// successes, draws), tabulated on [lo, hi] by the ratio of successive probabilities
+// and normalized over that window, which must hold all but a negligible mass.
+// Unlike differences of log-factorials, this stays accurate for huge totals.
+func hypergeometricPMF(total, successes, draws, lo, hi int) func(int) float64 {
+	if lowest := draws - (total - successes); lo < lowest {
+		lo = lowest
+	}
+	if lo < 0 {
+		lo = 0
+	}
+	if hi > successes {
+		hi = successes
+	}
+	if hi > draws {
+		hi = draws
+	}
+	logP := make([]float64, hi-lo+1) // log(P(k) / P(lo))
+	maxLogP := 0.0
+	for k := lo; k < hi; k++ {
+		ratio := float64(successes-k) * float64(draws-k) / (float64(k+1) * float64(total-successes-draws+k+1))
+		logP[k-lo+1] = logP[k-lo] + math.Log(ratio)
+		maxLogP = math.Max(maxLogP, logP[k-lo+1])
+	}
+	probs := make([]float64, len(logP))
+	var sum float64
+	for i, l := range logP {
+		probs[i] = math.Exp(l - maxLogP)
+		sum += probs[i]
+	}
+	return func(k int) float64 {
+		if k < lo || k > hi {
+			return 0
+		}
+		return probs[k-lo] / sum
+	}
+}
+
+// TestHypergeometric runs chi-square tests on Hypergeometric for both the inversion
+// and the ratio-of-uniforms method, covering every symmetry of the parameters.
+func TestHypergeometric(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	for _, tc := range []struct{ total, successes, draws int }{
+		{52, 4, 5},
+		{52, 13, 5},
+		{60, 40, 8},
+		{100, 30, 20},
+		{100, 70, 80},
+		{1000, 100, 900},
+		{1000, 600, 300},
+		{1e9, 3e8, 5e8},
+		{1e9, 1e3, 1e8},
+		{1 << 50, 4, 1 << 49},
+		{1e15, 5, 4e14},
+		{1 << 50, 1 << 40, 1 << 20},
+		{1 << 60, 1 << 20, 1 << 59},
+	} {
+		total, successes, draws := tc.total, tc.successes, tc.draws
+		mean := float64(draws) * float64(successes) / float64(total)
+		width := int(10*math.Sqrt(mean)) + 10
+		pmf := hypergeometricPMF(total, successes, draws, int(mean)-width, int(mean)+width)
+		checkPMF(t, "Hypergeometric", 200000, func() int {
+			k := rng.Hypergeometric(total, successes, draws)
+			if k < 0 || k > successes || k > draws || draws-k > total-successes {
+				t.Fatalf("Hypergeometric(%d, %d, %d) returned out of range value: %d", total, successes, draws, k)
+			}
+			return k
+		}, pmf, int(mean)-width, int(mean)+width)
+	}
+
+	for _, tc := range []struct{ total, successes, draws, expected int }{
+		{0, 0, 0, 0},
+		{10, 0, 5, 0},
+		{10, 10, 5, 5},
+		{10, 4, 10, 4},
+		{10, 4, 0, 0},
+	} {
+		if k := rng.Hypergeometric(tc.total, tc.successes, tc.draws); k != tc.expected {
+			t.Errorf("Hypergeometric(%d, %d, %d) = %d, expected %d", tc.total, tc.successes, tc.draws, k, tc.expected)
+		}
+	}
+	checkPanics(t, map[string]func(){
+		"Hypergeometric(10, -1, 5)": func() { rng.Hypergeometric(10, -1, 5) },
+		"Hypergeometric(10, 11, 5)": func() { rng.Hypergeometric(10, 11, 5) },
+		"Hypergeometric(10, 5, -1)": func() { rng.Hypergeometric(10, 5, -1) },
+		"Hypergeometric(10, 5, 11)": func() { rng.Hypergeometric(10, 5, 11) },
+	})
+}
+
+// TestZipf runs chi-square tests on Zipf and checks that it yields the same values
+// as math/rand.Zipf over the same source.
+func TestZipf(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	for _, tc := range []struct {
+		s, v float64
+		imax uint64
+	}{
+		{1.1, 1, 1000},
+		{1.5, 1, 100},
+		{2.5, 3, 1e6},
+		{4, 1, 0},
+	} {
+		s, v, imax := tc.s, tc.v, tc.imax
+		var norm float64
+		for k := uint64(0); k <= imax; k++ {
+			norm += math.Pow(v+float64(k), -s)
+		}
+		pmf := func(k int) float64 {
+			if k < 0 || uint64(k) > imax {
+				return 0
+			}
+			return math.Pow(v+float64(k), -s) / norm
+		}
+		checkPMF(t, "Zipf", 200000, func() int {
+			k := rng.Zipf(s, v, imax)
+			if k > imax {
+				t.Fatalf("Zipf(%v, %v, %d) returned out of range value: %d", s, v, imax, k)
+			}
+			return int(k)
+		}, pmf, 0, 1000)
+
+		ours := random.New(rand.NewSource(seed))
+		theirs := rand.NewZipf(rand.New(rand.NewSource(seed)), s, v, imax)
+		for i := 0; i < 10000; i++ {
+			if x, y := ours.Zipf(s, v, imax), theirs.Uint64(); x != y {
+				t.Fatalf("Zipf(%v, %v, %d) draw %d = %d, math/rand.Zipf = %d", s, v, imax, i, x, y)
+			}
+		}
+	}
+
+	checkPanics(t, map[string]func(){
+		"Zipf(1, 1, 10)":   func() { rng.Zipf(1, 1, 10) },
+		"Zipf(2, 0.5, 10)": func() { rng.Zipf(2, 0.5, 10) },
+		"Zipf(NaN, 1, 10)": func() { rng.Zipf(math.NaN(), 1, 10) },
+	})
+}
+
+// TestMultinomial runs a chi-square test on the joint distribution of small Multinomial
+// counts, checks that the counts of repeated Float64w draws pass the same test, and
+// checks the marginals and covariances for a large number of trials.
+func TestMultinomial(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	const n = 6
+	probs := []float64{1, 0, 2, 3.5, 0}
+	// Encode the counts of categories 0, 2 and 3 as one integer in base n+1.
+	encode := func(counts []int) int {
+		return counts[0] + (n+1)*counts[2] + (n+1)*(n+1)*counts[3]
+	}
+	pmf := func(key int) float64 {
+		c0, c2, c3 := key%(n+1), key/(n+1)%(n+1), key/((n+1)*(n+1))
+		if c0+c2+c3 != n {
+			return 0
+		}
+		lf := func(k int) float64 { lg, _ := math.Lgamma(float64(k) + 1); return lg }
+		return math.Exp(lf(n) - lf(c0) - lf(c2) - lf(c3) +
+			float64(c0)*math.Log(1/6.5) + float64(c2)*math.Log(2/6.5) + float64(c3)*math.Log(3.5/6.5))
+	}
+
+	counts := make([]int, len(probs))
+	checkPMF(t, "Multinomial", 200000, func() int {
+		rng.Multinomial(n, probs, counts)
+		if counts[1] != 0 || counts[4] != 0 || counts[0]+counts[2]+counts[3] != n {
+			t.Fatalf("Multinomial(%d, %v) returned invalid counts: %v", n, probs, counts)
+		}
+		return encode(counts)
+	}, pmf, 0, (n+1)*(n+1)*(n+1))
+
+	checkPMF(t, "Float64w counts", 200000, func() int {
+		for i := range counts {
+			counts[i] = 0
+		}
+		for i := 0; i < n; i++ {
+			counts[rng.Float64w(probs)]++
+		}
+		return encode(counts)
+	}, pmf, 0, (n+1)*(n+1)*(n+1))
+
+	// Large number of trials: every marginal is binomial and the covariances are -n*p_i*p_j.
+	const trials = 1000000
+	large := []float64{0.5, 0.2, 0.2, 0.09, 0.01}
+	mean := make([]float64, len(large))
+	cov := make([][]float64, len(large))
+	for i, p := range large {
+		mean[i] = trials * p
+		cov[i] = make([]float64, len(large))
+		for j, q := range large {
+			cov[i][j] = -trials * p * q
+		}
+		cov[i][i] = trials * p * (1 - p)
+	}
+	checkCovariance(t, "Multinomial", 20000, func(x []float64) {
+		rng.Multinomial(trials, large, counts)
+		for i, c := range counts {
+			x[i] = float64(c)
+		}
+	}, mean, cov)
+	for _, i := range []int{0, 3, 4} {
+		i := i
+		width := int(10*math.Sqrt(cov[i][i])) + 10
+		checkPMF(t, "Multinomial marginal", 20000, func() int {
+			rng.Multinomial(trials, large, counts)
+			return counts[i]
+		}, binomialPMF(trials, large[i]), int(mean[i])-width, int(mean[i])+width)
+	}
+
+	// A tiny probability among a huge number of trials.
+	tiny := []float64{5, 1 << 60}
+	checkPMF(t, "Multinomial tiny marginal", 20000, func() int {
+		rng.Multinomial(1<<60, tiny, counts[:2])
+		return counts[0]
+	}, binomialPMF(1<<60, 5/(1<<60+5.0)), 0, 40)
+
+	rng.Multinomial(0, probs, counts)
+	if !isEqual(counts, []int{0, 0, 0, 0, 0}) {
+		t.Errorf("Multinomial(0, %v) = %v, expected all zeros", probs, counts)
+	}
+}
+
+// TestMultinomialErrors tests that TryMultinomial rejects invalid arguments and
+// that Multinomial panics on them.
+func TestMultinomialErrors(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	testCases := []struct {
+		name  string
+		n     int
+		probs []float64
+		out   []int
+		err   error
+	}{
+		{"Empty", 1, []float64{}, []int{}, random.ErrEmptyWeights},
+		{"Negative", 1, []float64{1, -1}, make([]int, 2), random.ErrNegativeWeight},
+		{"NaN", 1, []float64{math.NaN()}, make([]int, 1), random.ErrNonFiniteWeight},
+		{"ZeroTotal", 1, []float64{0, 0}, make([]int, 2), random.ErrZeroTotal},
+		{"LengthMismatch", 1, []float64{1, 2}, make([]int, 1), random.ErrLengthMismatch},
+		{"NegativeTrials", -1, []float64{1, 2}, make([]int, 2), random.ErrSampleSize},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := rng.TryMultinomial(tc.n, tc.probs, tc.out); !errors.Is(err, tc.err) {
+				t.Errorf("expected error %v, got %v", tc.err, err)
+			}
+			checkPanics(t, map[string]func(){
+				"Multinomial": func() { rng.Multinomial(tc.n, tc.probs, tc.out) },
+			})
+		})
+	}
+
+	counts := make([]int, 3)
+	if allocs := testing.AllocsPerRun(1000, func() { rng.Multinomial(1000, []float64{1, 2, 3}, counts) }); allocs != 0 {
+		t.Errorf("Multinomial allocated %v times per run, expected 0", allocs)
+	}
+}
+
+// TestDiscreteSafeRandom tests the SafeRandom counterparts.
+func TestDiscreteSafeRandom(t *testing.T) {
+	safe := random.NewSafeRandom(rand.NewSource(seed))
+	checkPMF(t, "SafeRandom.Poisson", 100000, func() int { return safe.Poisson(20) }, poissonPMF(20), 0, 60)
+	checkPMF(t, "SafeRandom.Binomial", 100000, func() int { return safe.Binomial(50, 0.4) }, binomialPMF(50, 0.4), 0, 50)
+	checkPMF(t, "SafeRandom.Zipf", 100000, func() int { return int(safe.Zipf(2, 1, 50)) }, func(k int) float64 {
+		var norm float64
+		for i := 1; i <= 51; i++ {
+			norm += 1 / float64(i*i)
+		}
+		return 1 / float64((k+1)*(k+1)) / norm
+	}, 0, 50)
+	counts := make([]int, 2)
+	checkPMF(t, "SafeRandom.Multinomial", 100000, func() int {
+		safe.Multinomial(50, []float64{0.4, 0.6}, counts)
+		return counts[0]
+	}, binomialPMF(50, 0.4), 0, 50)
+	if err := safe.TryMultinomial(5, []float64{1}, counts); !errors.Is(err, random.ErrLengthMismatch) {
+		t.Errorf("SafeRandom.TryMultinomial: expected error %v, got %v", random.ErrLengthMismatch, err)
+	}
+	checkMoments(t, "SafeRandom.Geometric", 100000, func() float64 { return float64(safe.Geometric(0.25)) }, 3, 12, 0.05)
+	checkMoments(t, "SafeRandom.NegativeBinomial", 100000, func() float64 { return float64(safe.NegativeBinomial(4, 0.5)) }, 4, 8, 0.05)
+	checkMoments(t, "SafeRandom.Hypergeometric", 100000, func() float64 { return float64(safe.Hypergeometric(50, 20, 10)) }, 4, 10*0.4*0.6*40/49, 0.05)
+}
+
+func Benchmark_Poisson(b *testing.B) {
+	for n := b.N; n > 0; n-- {
+		rng.Poisson(100)
+	}
+}
+
+func Benchmark_Binomial(b *testing.B) {
+	for n := b.N; n > 0; n-- {
+		rng.Binomial(1000, 0.3)
+	}
+}
+
+func Benchmark_Geometric(b *testing.B) {
+	for n := b.N; n > 0; n-- {
+		rng.Geometric(0.01)
+	}
+}
+
+func Benchmark_NegativeBinomial(b *testing.B) {
+	for n := b.N; n > 0; n-- {
+		rng.NegativeBinomial(10, 0.3)
+	}
+}
+
+func Benchmark_Hypergeometric(b *testing.B) {
+	for n := b.N; n > 0; n-- {
+		rng.Hypergeometric(1000, 300, 200)
+	}
+}
+
+func Benchmark_Zipf(b *testing.B) {
+	for n := b.N; n > 0; n-- {
+		rng.Zipf(1.1, 1, 1e6)
+	}
+}
+
+func Benchmark_Multinomial(b *testing.B) {
+	probs := []float64{0.5, 0.2, 0.2, 0.09, 0.01}
+	counts := make([]int, len(probs))
+	for n := b.N; n > 0; n-- {
+		rng.Multinomial(1000, probs, counts)
+	}
+}
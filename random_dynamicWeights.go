@@ -0,0 +1,160 @@
+package random
+
+import (
+	"fmt"
+	"math"
+)
+
+// DynamicWeights is a weight table that supports updates and weighted draws in O(log n).
+// It is backed by a segment tree whose inner nodes hold the sum of their children,
+// so every update recomputes the affected sums exactly instead of accumulating
+// rounding error. Items keep their index for the lifetime of the table.
+//
+// DynamicWeights is not safe for concurrent use.
+type DynamicWeights struct {
+	n    int       // number of items
+	size int       // number of leaves, a power of two >= n
+	tree []float64 // tree[1] is the root, leaves are tree[size : size+n]
+}
+
+// NewDynamicWeights creates a DynamicWeights holding weights.
+// It returns an error if weights contains negative or non-finite values.
+// Unlike NewAlias, an empty or all-zero table is allowed.
+func NewDynamicWeights[W Number](weights []W) (*DynamicWeights, error) {
+	for i, w := range weights {
+		if err := checkWeight(float64(w)); err != nil {
+			return nil, fmt.Errorf("%w at index %d", err, i)
+		}
+	}
+
+	d := &DynamicWeights{}
+	d.grow(len(weights))
+	for i, w := range weights {
+		d.tree[d.size+i] = float64(w)
+	}
+	d.n = len(weights)
+	for i := d.size - 1; i > 0; i-- {
+		d.tree[i] = d.tree[2*i] + d.tree[2*i+1]
+	}
+	return d, nil
+}
+
+// Len returns the number of items, including those removed.
+func (d *DynamicWeights) Len() int {
+	return d.n
+}
+
+// Weight returns the weight of item i.
+func (d *DynamicWeights) Weight(i int) float64 {
+	d.checkIndex(i)
+	return d.tree[d.size+i]
+}
+
+// Total returns the sum of all weights.
+func (d *DynamicWeights) Total() float64 {
+	if d.size == 0 {
+		return 0
+	}
+	return d.tree[1]
+}
+
+// Set changes the weight of item i to w.
+// It returns an error if w is negative or non-finite. Panics if i is out of range.
+func (d *DynamicWeights) Set(i int, w float64) error {
+	d.checkIndex(i)
+	if err := checkWeight(w); err != nil {
+		return err
+	}
+	d.update(i, w)
+	return nil
+}
+
+// Add appends an item with weight w and returns its index.
+// It returns an error if w is negative or non-finite.
+func (d *DynamicWeights) Add(w float64) (int, error) {
+	if err := checkWeight(w); err != nil {
+		return 0, err
+	}
+	if d.n == d.size {
+		d.grow(d.n + 1)
+	}
+	i := d.n
+	d.n++
+	d.update(i, w)
+	return i, nil
+}
+
+// Remove sets the weight of item i to zero so that it is never sampled.
+// The indices of the other items are unchanged. Panics if i is out of range.
+func (d *DynamicWeights) Remove(i int) {
+	d.checkIndex(i)
+	d.update(i, 0)
+}
+
+// Sample randomly picks an item index using r. The probability of picking
+// item i is Weight(i) / Total(); items with zero weight are never picked.
+// Panics with ErrZeroTotal if the table is empty or all weights are zero,
+// or with ErrWeightOverflow if the weights sum to infinity.
+func (d *DynamicWeights) Sample(r *Random) int {
+	return mustWeightedIndex(d.TrySample(r))
+}
+
+// TrySample is like Sample but returns an error instead of panicking.
+func (d *DynamicWeights) TrySample(r *Random) (int, error) {
+	total := d.Total()
+	if total == 0 {
+		return 0, ErrZeroTotal
+	}
+	if math.IsInf(total, 1) {
+		return 0, ErrWeightOverflow
+	}
+
+	target := r.rand.Float64() * total
+	node := 1
+	for node < d.size {
+		left, right := d.tree[2*node], d.tree[2*node+1]
+		// Descend into a child with a positive sum only, so that rounding
+		// can never lead to an item with zero weight.
+		if target < left || right == 0 {
+			node = 2 * node
+		} else {
+			target -= left
+			node = 2*node + 1
+		}
+	}
+	return node - d.size, nil
+}
+
+// update sets leaf i to w and recomputes the sums on its path to the root.
+func (d *DynamicWeights) update(i int, w float64) {
+	node := d.size + i
+	d.tree[node] = w
+	for node > 1 {
+		node /= 2
+		d.tree[node] = d.tree[2*node] + d.tree[2*node+1]
+	}
+}
+
+// grow reallocates the tree so that it can hold at least n items.
+func (d *DynamicWeights) grow(n int) {
+	size := 1
+	for size < n {
+		size *= 2
+	}
+	tree := make([]float64, 2*size)
+	if d.size > 0 {
+		copy(tree[size:], d.tree[d.size:d.size+d.n])
+		for i := size - 1; i > 0; i-- {
+			tree[i] = tree[2*i] + tree[2*i+1]
+		}
+	}
+	d.size = size
+	d.tree = tree
+}
+
+// checkIndex panics if i is not a valid item index.
+func (d *DynamicWeights) checkIndex(i int) {
+	if i < 0 || i >= d.n {
+		panic(fmt.Sprintf("random: DynamicWeights index %d out of range [0, %d)", i, d.n))
+	}
+}
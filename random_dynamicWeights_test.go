@@ -0,0 +1,155 @@
+package random_test
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/bofry/random"
+)
+
+// TestDynamicWeightsMatchesFloat64w tests that, for integer-valued weights, Sample picks
+// exactly the same indices as Float64w given the same random stream, across updates.
+func TestDynamicWeightsMatchesFloat64w(t *testing.T) {
+	weights := []float64{3, 1, 4, 1, 5, 9, 2, 6, 5}
+	d, err := random.NewDynamicWeights(weights)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	src := random.New(rand.NewSource(seed))
+	a := random.New(rand.NewSource(seed))
+	b := random.New(rand.NewSource(seed))
+	for round := 0; round < 200; round++ {
+		for i := 0; i < 100; i++ {
+			if want, got := a.Float64w(weights), d.Sample(b); want != got {
+				t.Fatalf("round %d: Float64w picked %d, Sample picked %d (weights %v)", round, want, got, weights)
+			}
+		}
+
+		// Mutate the table through every kind of update.
+		switch i := src.Intn(len(weights)); round % 4 {
+		case 0, 1:
+			w := float64(src.Intn(10))
+			if err := d.Set(i, w); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			weights[i] = w
+		case 2:
+			w := float64(src.Intr(1, 10))
+			index, err := d.Add(w)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if index != len(weights) {
+				t.Fatalf("Add returned index %d, expected %d", index, len(weights))
+			}
+			weights = append(weights, w)
+		case 3:
+			d.Remove(i)
+			weights[i] = 0
+		}
+		if d.Total() == 0 {
+			d.Set(0, 1)
+			weights[0] = 1
+		}
+
+		if d.Len() != len(weights) {
+			t.Fatalf("Len returned %d, expected %d", d.Len(), len(weights))
+		}
+		var total float64
+		for i, w := range weights {
+			total += w
+			if d.Weight(i) != w {
+				t.Fatalf("Weight(%d) returned %v, expected %v", i, d.Weight(i), w)
+			}
+		}
+		if d.Total() != total {
+			t.Fatalf("Total returned %v, expected %v", d.Total(), total)
+		}
+	}
+}
+
+// TestDynamicWeightsDistribution runs a chi-square test on non-integer weights.
+func TestDynamicWeightsDistribution(t *testing.T) {
+	const rounds = 200000
+	d, err := random.NewDynamicWeights([]float32{0.5, 0.1, 0.7})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d.Set(0, 0.1)
+	d.Set(1, 0.2)
+	d.Set(2, 0.3)
+	d.Add(0.4)
+	d.Add(0.3)
+	d.Remove(4)
+	probs := []float64{0.1, 0.2, 0.3, 0.4, 0}
+
+	rng := random.New(rand.NewSource(seed))
+	counts := make([]int, d.Len())
+	for i := 0; i < rounds; i++ {
+		counts[d.Sample(rng)]++
+	}
+	checkChiSquare(t, "DynamicWeights", counts, probs)
+}
+
+// TestDynamicWeightsErrors tests invalid weights and empty tables.
+func TestDynamicWeightsErrors(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+
+	if _, err := random.NewDynamicWeights([]int{1, -1}); !errors.Is(err, random.ErrNegativeWeight) {
+		t.Errorf("expected ErrNegativeWeight, got %v", err)
+	}
+
+	d, err := random.NewDynamicWeights([]float64{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := d.TrySample(rng); !errors.Is(err, random.ErrZeroTotal) {
+		t.Errorf("expected ErrZeroTotal, got %v", err)
+	}
+	if _, err := d.Add(math.NaN()); !errors.Is(err, random.ErrNonFiniteWeight) {
+		t.Errorf("expected ErrNonFiniteWeight, got %v", err)
+	}
+	index, _ := d.Add(2)
+	if err := d.Set(index, -1); !errors.Is(err, random.ErrNegativeWeight) {
+		t.Errorf("expected ErrNegativeWeight, got %v", err)
+	}
+	if got := d.Sample(rng); got != index {
+		t.Errorf("Sample returned %d, expected %d", got, index)
+	}
+	d.Remove(index)
+	if _, err := d.TrySample(rng); !errors.Is(err, random.ErrZeroTotal) {
+		t.Errorf("expected ErrZeroTotal, got %v", err)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Set with an out of range index did not panic as expected")
+		}
+	}()
+	d.Set(5, 1)
+}
+
+func Benchmark_DynamicWeights_Sample(b *testing.B) {
+	ws := make([]float64, 300)
+	for i := range ws {
+		ws[i] = float64(i%7 + 1)
+	}
+	d, _ := random.NewDynamicWeights(ws)
+	for n := b.N; n > 0; n-- {
+		d.Sample(rng)
+	}
+}
+
+func Benchmark_DynamicWeights_Set(b *testing.B) {
+	ws := make([]float64, 300)
+	for i := range ws {
+		ws[i] = float64(i%7 + 1)
+	}
+	d, _ := random.NewDynamicWeights(ws)
+	for n := b.N; n > 0; n-- {
+		d.Set(n%300, float64(n%7+1))
+	}
+}
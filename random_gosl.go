@@ -33,18 +33,14 @@
 
 package random
 
-import "math/big"
-
 // Int63r generates a pseudo-random int64 between low (inclusive) and high (inclusive).
+// Any range is supported, including [math.MinInt64, math.MaxInt64].
 func (r *Random) Int63r(low, high int64) int64 {
 	if low > high {
 		low, high = high, low // Swap if low is greater than high
 	}
-	rangeBig := big.NewInt(0).Sub(big.NewInt(high), big.NewInt(low))
-	rangeBig.Add(rangeBig, big.NewInt(1)) // Add 1 to include high
-	offsetBig := big.NewInt(0).Mod(big.NewInt(r.Int63()), rangeBig)
-	resultBig := big.NewInt(0).Add(offsetBig, big.NewInt(low))
-	return resultBig.Int64()
+	// The span is computed in two's complement, so it cannot overflow.
+	return int64(uint64(low) + r.uint64r(uint64(high)-uint64(low)))
 }
 
 // Int63s generates a slice of pseudo-random int64 values between low (inclusive) and high (inclusive).
@@ -60,11 +56,12 @@ func (r *Random) Int63Shuffle(values []int64) {
 }
 
 // Uint32r generates a pseudo-random uint32 between low (inclusive) and high (inclusive).
+// Any range is supported, including [0, math.MaxUint32].
 func (r *Random) Uint32r(low, high uint32) uint32 {
 	if low > high {
 		low, high = high, low
 	}
-	return low + r.Uint32()%(high-low+1) // No overflow risk with uint32
+	return low + uint32(r.uint64r(uint64(high-low)))
 }
 
 // Uint32s generates a slice of pseudo-random uint32 values between low (inclusive) and high (inclusive).
@@ -80,15 +77,12 @@ func (r *Random) Uint32Shuffle(values []uint32) {
 }
 
 // Uint64r generates a pseudo-random uint64 between low (inclusive) and high (inclusive).
+// Any range is supported, including [0, math.MaxUint64].
 func (r *Random) Uint64r(low, high uint64) uint64 {
 	if low > high {
 		low, high = high, low
 	}
-	rangeBig := big.NewInt(0).Sub(big.NewInt(int64(high)), big.NewInt(int64(low)))
-	rangeBig.Add(rangeBig, big.NewInt(1))
-	offsetBig := big.NewInt(0).Mod(big.NewInt(int64(r.Uint64())), rangeBig)
-	resultBig := big.NewInt(0).Add(offsetBig, big.NewInt(int64(low)))
-	return resultBig.Uint64()
+	return low + r.uint64r(high-low)
 }
 
 // Uint64s generates a slice of pseudo-random uint64 values between low (inclusive) and high (inclusive).
@@ -104,11 +98,9 @@ func (r *Random) Uint64Shuffle(values []uint64) {
 }
 
 // Int31r generates a pseudo-random int32 between low (inclusive) and high (inclusive).
+// Any range is supported, including [math.MinInt32, math.MaxInt32].
 func (r *Random) Int31r(low, high int32) int32 {
-	if low > high {
-		low, high = high, low
-	}
-	return low + r.Int31n(high-low+1) // No overflow risk with int31n
+	return int32(r.Int63r(int64(low), int64(high)))
 }
 
 // Int31s generates a slice of pseudo-random int32 values between low (inclusive) and high (inclusive).
@@ -127,11 +119,9 @@ func (r *Random) Int31Shuffle(values []int32) {
 }
 
 // Intr generates a pseudo-random int between low (inclusive) and high (inclusive).
+// Any range is supported, including [math.MinInt, math.MaxInt].
 func (r *Random) Intr(low, high int) int {
-	if low > high {
-		low, high = high, low
-	}
-	return low + r.Intn(high-low+1) // No overflow risk with Intn
+	return int(r.Int63r(int64(low), int64(high)))
 }
 
 // Ints generates a slice of pseudo-random int values between low (inclusive) and high (inclusive).
@@ -1,8 +1,13 @@
 package random_test
 
 import (
+	"math"
+	"math/rand"
 	"reflect"
 	"testing"
+	"testing/quick"
+
+	"github.com/bofry/random"
 )
 
 // TestRangeInt63 tests Int63r for different ranges and data types.
@@ -30,6 +35,214 @@ func TestRangeInt63(t *testing.T) {
 	}
 }
 
+// TestRangeInt63Boundaries tests Int63r at the edges of the int64 domain.
+func TestRangeInt63Boundaries(t *testing.T) {
+	testCases := []struct {
+		name string
+		low  int64
+		high int64
+	}{
+		{"Full Range", math.MinInt64, math.MaxInt64},
+		{"Swapped Full Range", math.MaxInt64, math.MinInt64},
+		{"Top", math.MaxInt64 - 1, math.MaxInt64},
+		{"Bottom", math.MinInt64, math.MinInt64 + 1},
+		{"Max Only", math.MaxInt64, math.MaxInt64},
+		{"Min Only", math.MinInt64, math.MinInt64},
+		{"Across Zero", -3, 3},
+		{"Upper Half", 0, math.MaxInt64},
+		{"Lower Half", math.MinInt64, -1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			low, high := tc.low, tc.high
+			if low > high {
+				low, high = high, low
+			}
+			for i := 0; i < 1000; i++ {
+				num := rng.Int63r(tc.low, tc.high)
+				if num < low || num > high {
+					t.Errorf("Int63r(%d, %d) returned out of range value: %d", tc.low, tc.high, num)
+				}
+			}
+		})
+	}
+
+	// The full range must reach both signs.
+	var neg, pos bool
+	for i := 0; i < 100; i++ {
+		num := rng.Int63r(math.MinInt64, math.MaxInt64)
+		neg = neg || num < 0
+		pos = pos || num >= 0
+	}
+	if !neg || !pos {
+		t.Error("Int63r(math.MinInt64, math.MaxInt64) does not cover the whole domain")
+	}
+}
+
+// TestRangeUint64Boundaries tests Uint64r at the edges of the uint64 domain.
+func TestRangeUint64Boundaries(t *testing.T) {
+	testCases := []struct {
+		name string
+		low  uint64
+		high uint64
+	}{
+		{"Full Range", 0, math.MaxUint64},
+		{"Swapped Full Range", math.MaxUint64, 0},
+		{"Top", math.MaxUint64 - 1, math.MaxUint64},
+		{"Bottom", 0, 1},
+		{"Max Only", math.MaxUint64, math.MaxUint64},
+		{"Zero Only", 0, 0},
+		{"Above 2^63", 1 << 63, 1<<63 + 10},
+		{"Across 2^63", 1<<63 - 5, 1<<63 + 5},
+		{"Upper Half", 1 << 63, math.MaxUint64},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			low, high := tc.low, tc.high
+			if low > high {
+				low, high = high, low
+			}
+			for i := 0; i < 1000; i++ {
+				num := rng.Uint64r(tc.low, tc.high)
+				if num < low || num > high {
+					t.Errorf("Uint64r(%d, %d) returned out of range value: %d", tc.low, tc.high, num)
+				}
+			}
+		})
+	}
+
+	// Small ranges above 2^63 must hit every value.
+	seen := make(map[uint64]bool)
+	for i := 0; i < 1000; i++ {
+		seen[rng.Uint64r(1<<63-5, 1<<63+5)] = true
+	}
+	if len(seen) != 11 {
+		t.Errorf("Uint64r(2^63-5, 2^63+5) produced %d distinct values, expected 11", len(seen))
+	}
+
+	// The full range must reach the upper half.
+	var upper bool
+	for i := 0; i < 100; i++ {
+		upper = upper || rng.Uint64r(0, math.MaxUint64) >= 1<<63
+	}
+	if !upper {
+		t.Error("Uint64r(0, math.MaxUint64) does not cover the whole domain")
+	}
+}
+
+// TestRangeProperties checks, for arbitrary bounds over the whole domain of every
+// integer width, that the inclusive-range functions never leave [min(low, high), max(low, high)].
+func TestRangeProperties(t *testing.T) {
+	config := &quick.Config{MaxCount: 10000, Rand: rand.New(rand.NewSource(seed))}
+
+	properties := []struct {
+		name     string
+		property interface{}
+	}{
+		{"Int63r", func(low, high int64) bool {
+			v := rng.Int63r(low, high)
+			return (v >= low && v <= high) || (v >= high && v <= low)
+		}},
+		{"Int31r", func(low, high int32) bool {
+			v := rng.Int31r(low, high)
+			return (v >= low && v <= high) || (v >= high && v <= low)
+		}},
+		{"Intr", func(low, high int) bool {
+			v := rng.Intr(low, high)
+			return (v >= low && v <= high) || (v >= high && v <= low)
+		}},
+		{"Uint64r", func(low, high uint64) bool {
+			v := rng.Uint64r(low, high)
+			return (v >= low && v <= high) || (v >= high && v <= low)
+		}},
+		{"Uint32r", func(low, high uint32) bool {
+			v := rng.Uint32r(low, high)
+			return (v >= low && v <= high) || (v >= high && v <= low)
+		}},
+	}
+
+	for _, p := range properties {
+		t.Run(p.name, func(t *testing.T) {
+			if err := quick.Check(p.property, config); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// TestRangeExtremes tests the full-domain ranges that used to overflow.
+func TestRangeExtremes(t *testing.T) {
+	var intNeg, intPos, int31Neg, int31Pos, uint32Upper bool
+	for i := 0; i < 1000; i++ {
+		v := rng.Intr(math.MinInt, math.MaxInt)
+		intNeg, intPos = intNeg || v < 0, intPos || v >= 0
+
+		v31 := rng.Int31r(math.MinInt32, math.MaxInt32)
+		int31Neg, int31Pos = int31Neg || v31 < 0, int31Pos || v31 >= 0
+
+		uint32Upper = uint32Upper || rng.Uint32r(0, math.MaxUint32) >= 1<<31
+
+		if v := rng.Intr(math.MaxInt-1, math.MaxInt); v < math.MaxInt-1 {
+			t.Errorf("Intr(math.MaxInt-1, math.MaxInt) returned out of range value: %d", v)
+		}
+		if v := rng.Int31r(math.MinInt32, math.MinInt32+1); v > math.MinInt32+1 {
+			t.Errorf("Int31r(math.MinInt32, math.MinInt32+1) returned out of range value: %d", v)
+		}
+	}
+	if !intNeg || !intPos {
+		t.Error("Intr(math.MinInt, math.MaxInt) does not cover the whole domain")
+	}
+	if !int31Neg || !int31Pos {
+		t.Error("Int31r(math.MinInt32, math.MaxInt32) does not cover the whole domain")
+	}
+	if !uint32Upper {
+		t.Error("Uint32r(0, math.MaxUint32) does not cover the whole domain")
+	}
+}
+
+// TestRangeUniform runs a chi-square test on small ranges of every integer width.
+func TestRangeUniform(t *testing.T) {
+	const rounds = 70000
+	testCases := []struct {
+		name     string
+		function func() int // returns an offset in [0, 7)
+	}{
+		{"Int63r", func() int { return int(rng.Int63r(math.MaxInt64-6, math.MaxInt64) - (math.MaxInt64 - 6)) }},
+		{"Int31r", func() int { return int(rng.Int31r(math.MinInt32, math.MinInt32+6) - math.MinInt32) }},
+		{"Intr", func() int { return rng.Intr(-3, 3) + 3 }},
+		{"Uint64r", func() int { return int(rng.Uint64r(math.MaxUint64-6, math.MaxUint64) - (math.MaxUint64 - 6)) }},
+		{"Uint32r", func() int { return int(rng.Uint32r(0, 6)) }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			counts := make([]int, 7)
+			for i := 0; i < rounds; i++ {
+				counts[tc.function()]++
+			}
+			checkChiSquare(t, tc.name, counts, nil)
+		})
+	}
+}
+
+// TestRangeAllocs tests that the inclusive-range functions do not allocate.
+func TestRangeAllocs(t *testing.T) {
+	allocs := testing.AllocsPerRun(1000, func() {
+		rng.Int63r(math.MinInt64, math.MaxInt64)
+		rng.Int63r(-100, 100)
+		rng.Uint64r(0, math.MaxUint64)
+		rng.Uint64r(1<<63, 1<<63+100)
+		rng.Intr(math.MinInt, math.MaxInt)
+		rng.Int31r(math.MinInt32, math.MaxInt32)
+		rng.Uint32r(0, math.MaxUint32)
+	})
+	if allocs != 0 {
+		t.Errorf("inclusive-range functions allocated %v times per run, expected 0", allocs)
+	}
+}
+
 // TestInt63s tests the Int63s function to ensure it generates int64 slices correctly.
 func TestInt63s(t *testing.T) {
 	// Test with an empty slice
@@ -60,6 +273,54 @@ func TestInt63Shuffle(t *testing.T) {
 	}
 }
 
+// TestShuffleUniform tests that every *Shuffle function yields all n! permutations with equal frequency.
+func TestShuffleUniform(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+
+	checkUniformShuffle(t, "Int63Shuffle", rng.Int63Shuffle)
+	checkUniformShuffle(t, "Uint32Shuffle", rng.Uint32Shuffle)
+	checkUniformShuffle(t, "Uint64Shuffle", rng.Uint64Shuffle)
+	checkUniformShuffle(t, "Int31Shuffle", rng.Int31Shuffle)
+	checkUniformShuffle(t, "IntShuffle", rng.IntShuffle)
+	checkUniformShuffle(t, "Float64Shuffle", rng.Float64Shuffle)
+	checkUniformShuffle(t, "Float32Shuffle", rng.Float32Shuffle)
+}
+
+// checkUniformShuffle shuffles [0, 1, 2, 3] repeatedly and runs a chi-square test
+// over the 4! = 24 possible permutations, which must all appear.
+func checkUniformShuffle[T int | int32 | int64 | uint32 | uint64 | float32 | float64](t *testing.T, name string, shuffle func([]T)) {
+	t.Helper()
+	const (
+		n      = 4
+		perms  = 24
+		rounds = perms * 10000
+	)
+
+	counts := make(map[int]int)
+	values := make([]T, n)
+	for i := 0; i < rounds; i++ {
+		for j := range values {
+			values[j] = T(j)
+		}
+		shuffle(values)
+		key := 0
+		for _, v := range values {
+			key = key*n + int(v)
+		}
+		counts[key]++
+	}
+
+	if len(counts) != perms {
+		t.Errorf("%s: expected %d distinct permutations, got %d", name, perms, len(counts))
+		return
+	}
+	observed := make([]int, 0, perms)
+	for _, c := range counts {
+		observed = append(observed, c)
+	}
+	checkChiSquare(t, name, observed, nil)
+}
+
 // Similar tests for Uint32r, Uint32s, Uint32Shuffle, Uint64r, Uint64s, Uint64Shuffle...
 // Similar tests for Int31r, Int31s, Int31Shuffle, Intr, Ints, IntShuffle...
 // Similar tests for Float64r, Float64s, Float64Shuffle, Float32r, Float32s, Float32Shuffle...
@@ -0,0 +1,160 @@
+package random
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	ErrCovarianceShape = errors.New("random: covariance matrix does not match the mean in size")
+	ErrCovariance      = errors.New("random: covariance matrix is not symmetric positive semi-definite")
+	ErrMean            = errors.New("random: mean vector contains non-finite values")
+)
+
+// MultivariateNormal draws vectors from a multivariate normal distribution.
+// NewMultivariateNormal factors the covariance matrix once, and Sample writes
+// into a caller-supplied buffer without allocating. Goroutines may share a
+// MultivariateNormal, provided they pass Sample separate *Random values.
+type MultivariateNormal struct {
+	mean []float64
+	chol []float64 // lower triangular factor L with L*Lᵀ = cov, row-major n×n
+}
+
+// NewMultivariateNormal creates a MultivariateNormal with the given mean vector and
+// covariance matrix. Singular (positive semi-definite) covariance matrices are
+// allowed, e.g. for perfectly correlated components. Both arguments are copied.
+// It returns ErrCovarianceShape if cov is not len(mean)×len(mean), ErrMean if mean
+// contains non-finite values, or ErrCovariance if cov is not symmetric positive
+// semi-definite or contains non-finite values.
+func NewMultivariateNormal(mean []float64, cov [][]float64) (*MultivariateNormal, error) {
+	n := len(mean)
+	if len(cov) != n {
+		return nil, ErrCovarianceShape
+	}
+	for _, m := range mean {
+		if math.IsNaN(m) || math.IsInf(m, 0) {
+			return nil, ErrMean
+		}
+	}
+	var scale float64
+	for i, row := range cov {
+		if len(row) != n {
+			return nil, ErrCovarianceShape
+		}
+		for _, c := range row {
+			if math.IsNaN(c) || math.IsInf(c, 0) {
+				return nil, ErrCovariance
+			}
+		}
+		scale = math.Max(scale, math.Abs(row[i]))
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			if math.Abs(cov[i][j]-cov[j][i]) > 1e-12*scale {
+				return nil, ErrCovariance
+			}
+		}
+	}
+
+	// Cholesky–Banachiewicz, with pivots within rounding of zero treated as zero
+	// so that singular matrices are accepted.
+	pivotTol := 1e-12 * scale
+	residualTol := 1e-6 * scale
+	chol := make([]float64, n*n)
+	for j := 0; j < n; j++ {
+		d := cov[j][j]
+		for k := 0; k < j; k++ {
+			d -= chol[j*n+k] * chol[j*n+k]
+		}
+		if d < -pivotTol {
+			return nil, ErrCovariance
+		}
+		pivot := 0.0
+		if d > pivotTol {
+			pivot = math.Sqrt(d)
+		}
+		chol[j*n+j] = pivot
+		for i := j + 1; i < n; i++ {
+			s := cov[i][j]
+			for k := 0; k < j; k++ {
+				s -= chol[i*n+k] * chol[j*n+k]
+			}
+			if pivot > 0 {
+				chol[i*n+j] = s / pivot
+			} else if math.Abs(s) > residualTol {
+				return nil, ErrCovariance
+			}
+		}
+	}
+
+	return &MultivariateNormal{
+		mean: append([]float64(nil), mean...),
+		chol: chol,
+	}, nil
+}
+
+// Dim returns the number of components of each drawn vector.
+func (m *MultivariateNormal) Dim() int {
+	return len(m.mean)
+}
+
+// Sample fills out with a vector drawn using r.
+// Panics if len(out) != m.Dim().
+func (m *MultivariateNormal) Sample(r *Random, out []float64) {
+	n := len(m.mean)
+	if len(out) != n {
+		panic("invalid argument to MultivariateNormal.Sample")
+	}
+	for i := range out {
+		out[i] = r.StdNormal()
+	}
+	// Compute mean + L*z in place: row i only reads z[0..i], so going from the
+	// last row up never reads a component that was already overwritten.
+	for i := n - 1; i >= 0; i-- {
+		s := m.mean[i]
+		row := m.chol[i*n : i*n+i+1]
+		for k, l := range row {
+			s += l * out[k]
+		}
+		out[i] = s
+	}
+}
+
+// Dirichlet fills out with a vector drawn from the Dirichlet distribution with
+// concentration parameters alpha. The components of out are non-negative and sum to 1.
+// It normalizes gamma variates in log space, so very small alphas do not underflow
+// to 0/0.
+// Panics if len(out) != len(alpha), alpha is empty, or any alpha is <= 0 or infinite.
+func (r *Random) Dirichlet(alpha []float64, out []float64) {
+	if len(alpha) == 0 || len(out) != len(alpha) {
+		panic("invalid argument to Dirichlet")
+	}
+	for _, a := range alpha {
+		if !(a > 0) || math.IsInf(a, 1) {
+			panic("invalid argument to Dirichlet")
+		}
+	}
+
+	maxLog := math.Inf(-1)
+	for i, a := range alpha {
+		out[i] = r.logStdGamma(a)
+		maxLog = math.Max(maxLog, out[i])
+	}
+	var sum float64
+	for i := range out {
+		out[i] = math.Exp(out[i] - maxLog)
+		sum += out[i]
+	}
+	for i := range out {
+		out[i] /= sum
+	}
+}
+
+// logStdGamma returns the logarithm of a gamma distributed float64 with the given
+// shape and scale 1, without underflowing for small shapes.
+func (r *Random) logStdGamma(shape float64) float64 {
+	if shape < 1 {
+		return math.Log(r.stdGamma(shape+1)) + math.Log(r.float64Open())/shape
+	}
+	return math.Log(r.stdGamma(shape))
+}
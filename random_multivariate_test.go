@@ -0,0 +1,233 @@
+package random_test
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/bofry/random"
+)
+
+// checkCovariance draws rounds vectors of length len(mean) from sample and checks the
+// sample mean and covariance of every component against mean and cov, within 5
+// standard errors plus a small allowance for rounding.
+func checkCovariance(t *testing.T, name string, rounds int, sample func([]float64), mean []float64, cov [][]float64) {
+	t.Helper()
+	n := len(mean)
+	sum := make([]float64, n)
+	sumProd := make([][]float64, n)
+	for i := range sumProd {
+		sumProd[i] = make([]float64, n)
+	}
+	x := make([]float64, n)
+	for r := 0; r < rounds; r++ {
+		sample(x)
+		for i := range x {
+			sum[i] += x[i]
+			for j := range x {
+				sumProd[i][j] += x[i] * x[j]
+			}
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		m := sum[i] / float64(rounds)
+		if tolerance := 5*math.Sqrt(cov[i][i]/float64(rounds)) + 1e-9; math.Abs(m-mean[i]) > tolerance {
+			t.Errorf("%s: mean[%d] %.5f, expected %.5f ± %.5f", name, i, m, mean[i], tolerance)
+		}
+		for j := 0; j < n; j++ {
+			c := sumProd[i][j]/float64(rounds) - sum[i]*sum[j]/float64(rounds*rounds)
+			tolerance := 5*math.Sqrt((cov[i][i]*cov[j][j]+cov[i][j]*cov[i][j])/float64(rounds)) + 1e-9
+			if math.Abs(c-cov[i][j]) > tolerance {
+				t.Errorf("%s: cov[%d][%d] %.5f, expected %.5f ± %.5f", name, i, j, c, cov[i][j], tolerance)
+			}
+		}
+	}
+}
+
+// TestMultivariateNormal tests the moments and marginals of MultivariateNormal.
+func TestMultivariateNormal(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	mean := []float64{1, -2, 10}
+	cov := [][]float64{
+		{4, 1.2, -0.6},
+		{1.2, 1, 0.3},
+		{-0.6, 0.3, 0.5},
+	}
+	m, err := random.NewMultivariateNormal(mean, cov)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.Dim() != 3 {
+		t.Fatalf("expected Dim 3, got %d", m.Dim())
+	}
+	checkCovariance(t, "MultivariateNormal", 200000, func(x []float64) { m.Sample(rng, x) }, mean, cov)
+
+	x := make([]float64, 3)
+	for i := range mean {
+		i := i
+		checkKS(t, "MultivariateNormal marginal", 100000, func() float64 {
+			m.Sample(rng, x)
+			return x[i]
+		}, normalCDF(mean[i], math.Sqrt(cov[i][i])))
+	}
+
+	// The arguments are copied.
+	mean[0], cov[0][0] = 100, 100
+	checkCovariance(t, "MultivariateNormal copy", 100000, func(x []float64) { m.Sample(rng, x) },
+		[]float64{1, -2, 10}, [][]float64{{4, 1.2, -0.6}, {1.2, 1, 0.3}, {-0.6, 0.3, 0.5}})
+}
+
+// TestMultivariateNormalSingular tests covariance matrices with perfectly correlated
+// and constant components.
+func TestMultivariateNormalSingular(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	mean := []float64{0, 5, 3}
+	cov := [][]float64{
+		{1, 2, 0},
+		{2, 4, 0},
+		{0, 0, 0},
+	}
+	m, err := random.NewMultivariateNormal(mean, cov)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkCovariance(t, "MultivariateNormal singular", 100000, func(x []float64) { m.Sample(rng, x) }, mean, cov)
+
+	x := make([]float64, 3)
+	for i := 0; i < 1000; i++ {
+		m.Sample(rng, x)
+		if math.Abs(x[1]-5-2*x[0]) > 1e-9 || x[2] != 3 {
+			t.Fatalf("sample %v does not follow the singular covariance", x)
+		}
+	}
+}
+
+// TestMultivariateNormalErrors tests that NewMultivariateNormal rejects invalid arguments.
+func TestMultivariateNormalErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		mean []float64
+		cov  [][]float64
+		err  error
+	}{
+		{"Rows", []float64{0, 0}, [][]float64{{1, 0}}, random.ErrCovarianceShape},
+		{"Columns", []float64{0, 0}, [][]float64{{1, 0}, {0}}, random.ErrCovarianceShape},
+		{"Asymmetric", []float64{0, 0}, [][]float64{{1, 0.5}, {0.4, 1}}, random.ErrCovariance},
+		{"Indefinite", []float64{0, 0}, [][]float64{{1, 2}, {2, 1}}, random.ErrCovariance},
+		{"NegativeVariance", []float64{0}, [][]float64{{-1}}, random.ErrCovariance},
+		{"SingularInconsistent", []float64{0, 0}, [][]float64{{0, 1}, {1, 1}}, random.ErrCovariance},
+		{"NaN", []float64{0, 0}, [][]float64{{1, math.NaN()}, {math.NaN(), 1}}, random.ErrCovariance},
+		{"NaNMean", []float64{0, math.NaN()}, [][]float64{{1, 0}, {0, 1}}, random.ErrMean},
+		{"InfiniteMean", []float64{math.Inf(-1)}, [][]float64{{1}}, random.ErrMean},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := random.NewMultivariateNormal(tc.mean, tc.cov); !errors.Is(err, tc.err) {
+				t.Errorf("expected error %v, got %v", tc.err, err)
+			}
+		})
+	}
+
+	m, _ := random.NewMultivariateNormal([]float64{0, 0}, [][]float64{{1, 0}, {0, 1}})
+	rng := random.New(rand.NewSource(seed))
+	checkPanics(t, map[string]func(){
+		"Sample with a short buffer": func() { m.Sample(rng, make([]float64, 1)) },
+	})
+}
+
+// TestDirichlet tests the moments and marginals of Dirichlet, including small alphas.
+func TestDirichlet(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	for _, alpha := range [][]float64{
+		{1, 1, 1},
+		{2, 5, 0.5, 10},
+		{0.1, 0.2},
+		{1e-3, 1e-3, 1e-3},
+	} {
+		var alpha0 float64
+		for _, a := range alpha {
+			alpha0 += a
+		}
+		mean := make([]float64, len(alpha))
+		cov := make([][]float64, len(alpha))
+		for i, a := range alpha {
+			mean[i] = a / alpha0
+			cov[i] = make([]float64, len(alpha))
+			for j, b := range alpha {
+				cov[i][j] = -a * b / (alpha0 * alpha0 * (alpha0 + 1))
+			}
+			cov[i][i] = a * (alpha0 - a) / (alpha0 * alpha0 * (alpha0 + 1))
+		}
+
+		checkCovariance(t, "Dirichlet", 100000, func(x []float64) {
+			rng.Dirichlet(alpha, x)
+			var sum float64
+			for _, v := range x {
+				if !(v >= 0 && v <= 1) {
+					t.Fatalf("Dirichlet(%v) returned out of range component: %v", alpha, x)
+				}
+				sum += v
+			}
+			if math.Abs(sum-1) > 1e-12 {
+				t.Fatalf("Dirichlet(%v) returned components summing to %v", alpha, sum)
+			}
+		}, mean, cov)
+	}
+
+	// The first component of Dirichlet(1, 3) is Beta(1, 3) distributed.
+	x := make([]float64, 2)
+	checkKS(t, "Dirichlet marginal", 100000, func() float64 {
+		rng.Dirichlet([]float64{1, 3}, x)
+		return x[0]
+	}, func(x float64) float64 {
+		return 1 - math.Pow(1-x, 3)
+	})
+
+	safe := random.NewSafeRandom(rand.NewSource(seed))
+	checkCovariance(t, "SafeRandom.Dirichlet", 100000, func(x []float64) { safe.Dirichlet([]float64{2, 2}, x) },
+		[]float64{0.5, 0.5}, [][]float64{{0.05, -0.05}, {-0.05, 0.05}})
+
+	checkPanics(t, map[string]func(){
+		"Dirichlet(empty)":          func() { rng.Dirichlet(nil, nil) },
+		"Dirichlet(short buffer)":   func() { rng.Dirichlet([]float64{1, 1}, make([]float64, 1)) },
+		"Dirichlet(zero alpha)":     func() { rng.Dirichlet([]float64{1, 0}, make([]float64, 2)) },
+		"Dirichlet(NaN alpha)":      func() { rng.Dirichlet([]float64{math.NaN()}, make([]float64, 1)) },
+		"Dirichlet(infinite alpha)": func() { rng.Dirichlet([]float64{math.Inf(1)}, make([]float64, 1)) },
+	})
+}
+
+// TestMultivariateAllocs tests that MultivariateNormal.Sample and Dirichlet do not allocate.
+func TestMultivariateAllocs(t *testing.T) {
+	m, err := random.NewMultivariateNormal([]float64{0, 1}, [][]float64{{1, 0.5}, {0.5, 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rng := random.New(rand.NewSource(seed))
+	x := make([]float64, 2)
+	alpha := []float64{0.5, 3}
+	if allocs := testing.AllocsPerRun(1000, func() { m.Sample(rng, x) }); allocs != 0 {
+		t.Errorf("MultivariateNormal.Sample allocated %v times per run, expected 0", allocs)
+	}
+	if allocs := testing.AllocsPerRun(1000, func() { rng.Dirichlet(alpha, x) }); allocs != 0 {
+		t.Errorf("Dirichlet allocated %v times per run, expected 0", allocs)
+	}
+}
+
+func Benchmark_MultivariateNormal(b *testing.B) {
+	m, _ := random.NewMultivariateNormal([]float64{0, 0, 0}, [][]float64{{1, 0.5, 0}, {0.5, 1, 0.5}, {0, 0.5, 1}})
+	x := make([]float64, 3)
+	for n := b.N; n > 0; n-- {
+		m.Sample(rng, x)
+	}
+}
+
+func Benchmark_Dirichlet(b *testing.B) {
+	alpha := []float64{1, 2, 3}
+	x := make([]float64, 3)
+	for n := b.N; n > 0; n-- {
+		rng.Dirichlet(alpha, x)
+	}
+}
@@ -0,0 +1,148 @@
+package random
+
+import "math"
+
+// Ziggurat tables for the standard normal distribution (Marsaglia & Tsang, 2000).
+// The density f(x) = exp(-x²/2) is covered by zigLayers layers of equal area
+// zigArea; zigX[i] is the right edge of layer i and zigF[i] = f(zigX[i]).
+// Layer 0 is the base strip, whose pseudo-width zigX[0] accounts for the tail
+// beyond zigR.
+const (
+	zigLayers = 128
+	zigR      = 3.442619855899
+	zigArea   = 9.91256303526217e-3
+)
+
+var zigX, zigF = zigguratTables()
+
+// zigguratTables computes the layer edges and their densities.
+func zigguratTables() (x, f [zigLayers + 1]float64) {
+	fr := math.Exp(-0.5 * zigR * zigR)
+	x[0], f[0] = zigArea/fr, fr
+	x[1], f[1] = zigR, fr
+	for i := 1; i < zigLayers-1; i++ {
+		x[i+1] = math.Sqrt(-2 * math.Log(zigArea/x[i]+f[i]))
+		f[i+1] = math.Exp(-0.5 * x[i+1] * x[i+1])
+	}
+	x[zigLayers], f[zigLayers] = 0, 1
+	return x, f
+}
+
+// StdNormal returns a normally distributed float64 with mean 0 and standard deviation 1.
+// It uses the Ziggurat method on 64-bit draws, so it produces the same sequence for
+// any rand.Source seeded the same way, including mt19937.Rand.
+func (r *Random) StdNormal() float64 {
+	for {
+		u := r.rand.Uint64()
+		i := u & (zigLayers - 1)                  // layer, bits 0-6
+		negative := u&zigLayers != 0              // sign, bit 7
+		z := float64(u>>11) * 0x1.0p-53 * zigX[i] // position, bits 11-63
+
+		// Points left of zigX[i+1] lie in a rectangle entirely under the curve;
+		// the rest are in the base strip's tail or in a wedge that needs testing.
+		if z >= zigX[i+1] {
+			if i == 0 {
+				z = r.normalTail()
+			} else if zigF[i]+r.rand.Float64()*(zigF[i+1]-zigF[i]) >= math.Exp(-0.5*z*z) {
+				continue
+			}
+		}
+		if negative {
+			return -z
+		}
+		return z
+	}
+}
+
+// normalTail samples the standard normal distribution conditioned on x > zigR.
+func (r *Random) normalTail() float64 {
+	for {
+		x := -math.Log(r.float64Open()) / zigR
+		y := -math.Log(r.float64Open())
+		if y+y >= x*x {
+			return zigR + x
+		}
+	}
+}
+
+// Normal returns a normally distributed float64 with the given mean and standard deviation.
+// Panics if stddev < 0.
+func (r *Random) Normal(mean, stddev float64) float64 {
+	if !(stddev >= 0) {
+		panic("invalid argument to Normal")
+	}
+	return mean + stddev*r.StdNormal()
+}
+
+// TruncatedNormal returns a float64 from the normal distribution with the given mean and
+// standard deviation, conditioned on lying in [low, high]. Either bound may be infinite.
+// It uses C. P. Robert's algorithm ("Simulation of truncated normal variables", 1995),
+// which picks normal, uniform or exponential rejection depending on the window, so the
+// acceptance rate stays bounded even when the window lies far in a tail.
+// Panics if mean or stddev is not finite, stddev <= 0 or low >= high.
+func (r *Random) TruncatedNormal(mean, stddev, low, high float64) float64 {
+	if math.IsNaN(mean) || math.IsInf(mean, 0) || !(stddev > 0) || math.IsInf(stddev, 1) || !(low < high) {
+		panic("invalid argument to TruncatedNormal")
+	}
+	a := (low - mean) / stddev
+	b := (high - mean) / stddev
+	var z float64
+	switch {
+	case a >= 0:
+		z = r.truncatedStdNormalTail(a, b)
+	case b <= 0:
+		z = -r.truncatedStdNormalTail(-b, -a)
+	default:
+		z = r.truncatedStdNormalCentral(a, b)
+	}
+	// Guard against rounding pushing the result just outside the window.
+	return math.Min(math.Max(mean+stddev*z, low), high)
+}
+
+// truncatedStdNormalCentral samples the standard normal distribution conditioned
+// on [a, b] with a < 0 < b.
+func (r *Random) truncatedStdNormalCentral(a, b float64) float64 {
+	if b-a >= math.Sqrt(2*math.Pi) {
+		// The window holds at least half of one side of the density.
+		for {
+			if z := r.StdNormal(); z >= a && z <= b {
+				return z
+			}
+		}
+	}
+	for {
+		z := a + r.rand.Float64()*(b-a)
+		if r.rand.Float64() <= math.Exp(-0.5*z*z) {
+			return z
+		}
+	}
+}
+
+// truncatedStdNormalTail samples the standard normal distribution conditioned
+// on [a, b] with 0 <= a < b.
+func (r *Random) truncatedStdNormalTail(a, b float64) float64 {
+	// s = sqrt(a²+4) and (a²-a*s)/4 = -a/(a+s), computed without squaring a so
+	// that windows far in the tail do not overflow.
+	s := math.Hypot(a, 2)
+	if b-a < 2/(a+s)*math.Exp(0.5-a/(a+s)) {
+		// The window is narrow: uniform proposals beat exponential ones.
+		for {
+			z := a + r.rand.Float64()*(b-a)
+			if r.rand.Float64() <= math.Exp(0.5*(a-z)*(a+z)) {
+				return z
+			}
+		}
+	}
+	// Exponential proposals with the optimal rate.
+	alpha := (a + s) / 2
+	for {
+		z := a - math.Log(r.float64Open())/alpha
+		if z > b {
+			continue
+		}
+		d := z - alpha
+		if r.rand.Float64() <= math.Exp(-0.5*d*d) {
+			return z
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package random_test
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/bofry/random"
+	"github.com/bofry/random/mt19937"
+)
+
+// ksCritical is the Kolmogorov-Smirnov critical value of sqrt(n)*D at p = 0.001.
+const ksCritical = 1.95
+
+// checkKS runs a one-sample Kolmogorov-Smirnov test of n draws from sample against cdf.
+func checkKS(t *testing.T, name string, n int, sample func() float64, cdf func(float64) float64) {
+	t.Helper()
+	xs := make([]float64, n)
+	for i := range xs {
+		xs[i] = sample()
+	}
+	sort.Float64s(xs)
+
+	var d float64
+	for i, x := range xs {
+		c := cdf(x)
+		d = math.Max(d, math.Max(c-float64(i)/float64(n), float64(i+1)/float64(n)-c))
+	}
+	if stat := d * math.Sqrt(float64(n)); stat > ksCritical {
+		t.Errorf("%s: Kolmogorov-Smirnov statistic %.3f > %.2f", name, stat, ksCritical)
+	}
+}
+
+// normalCDF returns the cumulative distribution function of N(mean, stddev²).
+func normalCDF(mean, stddev float64) func(float64) float64 {
+	return func(x float64) float64 {
+		return 0.5 * math.Erfc(-(x-mean)/(stddev*math.Sqrt2))
+	}
+}
+
+// TestStdNormal runs a Kolmogorov-Smirnov test on StdNormal over different sources.
+func TestStdNormal(t *testing.T) {
+	mt := mt19937.New()
+	mt.Seed(seed)
+	sources := []struct {
+		name string
+		rng  *random.Random
+	}{
+		{"Go", random.New(rand.NewSource(seed))},
+		{"MT19937", random.New(mt)},
+	}
+
+	for _, src := range sources {
+		t.Run(src.name, func(t *testing.T) {
+			checkKS(t, "StdNormal", 200000, src.rng.StdNormal, normalCDF(0, 1))
+		})
+	}
+}
+
+// TestStdNormalTail tests that the tail beyond the base strip has the right mass.
+func TestStdNormalTail(t *testing.T) {
+	const rounds = 2000000
+	rng := random.New(rand.NewSource(seed))
+	var tail int
+	for i := 0; i < rounds; i++ {
+		if math.Abs(rng.StdNormal()) > 3 {
+			tail++
+		}
+	}
+	p := math.Erfc(3 / math.Sqrt2) // P(|Z| > 3)
+	tolerance := 5 * math.Sqrt(p*(1-p)/rounds)
+	if got := float64(tail) / rounds; math.Abs(got-p) > tolerance {
+		t.Errorf("P(|Z| > 3) = %.6f, expected %.6f", got, p)
+	}
+}
+
+// TestNormal tests Normal with a non-standard mean and standard deviation.
+func TestNormal(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	checkKS(t, "Normal(10, 2.5)", 100000, func() float64 { return rng.Normal(10, 2.5) }, normalCDF(10, 2.5))
+
+	safe := random.NewSafeRandom(rand.NewSource(seed))
+	checkKS(t, "SafeRandom.Normal(-3, 0.5)", 100000, func() float64 { return safe.Normal(-3, 0.5) }, normalCDF(-3, 0.5))
+
+	if v := rng.Normal(7, 0); v != 7 {
+		t.Errorf("Normal(7, 0) returned %v, expected 7", v)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Normal with a negative stddev did not panic as expected")
+		}
+	}()
+	rng.Normal(0, -1)
+}
+
+// TestStdNormalReproducible tests that equally seeded MT19937 sources give the same sequence.
+func TestStdNormalReproducible(t *testing.T) {
+	a, b := mt19937.New(), mt19937.New()
+	a.Seed(seed)
+	b.Seed(seed)
+	ra, rb := random.New(a), random.New(b)
+	for i := 0; i < 1000; i++ {
+		if x, y := ra.StdNormal(), rb.StdNormal(); x != y {
+			t.Fatalf("draw %d differs: %v and %v", i, x, y)
+		}
+	}
+}
+
+// truncatedNormalCDF returns the cumulative distribution function of N(mean, stddev²)
+// truncated to [low, high]. It works with upper tail probabilities when the window lies
+// above the mean, so that it stays accurate far in the tail.
+func truncatedNormalCDF(mean, stddev, low, high float64) func(float64) float64 {
+	a := (low - mean) / stddev
+	b := (high - mean) / stddev
+	upper := func(z float64) float64 { return 0.5 * math.Erfc(z/math.Sqrt2) }
+	lower := func(z float64) float64 { return 0.5 * math.Erfc(-z/math.Sqrt2) }
+	return func(x float64) float64 {
+		z := math.Min(math.Max((x-mean)/stddev, a), b)
+		if a >= 0 {
+			return (upper(a) - upper(z)) / (upper(a) - upper(b))
+		}
+		return (lower(z) - lower(a)) / (lower(b) - lower(a))
+	}
+}
+
+// TestTruncatedNormal runs Kolmogorov-Smirnov tests on TruncatedNormal for windows
+// covering every sampling method, including windows far in either tail.
+func TestTruncatedNormal(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	inf := math.Inf(1)
+	for _, tc := range []struct{ mean, stddev, low, high float64 }{
+		{0, 1, -1, 1},        // central, uniform proposals
+		{0, 1, -3, 5},        // central, normal proposals
+		{0, 1, -inf, inf},    // no truncation
+		{0, 1, 0, inf},       // half-normal
+		{0, 1, 2, inf},       // tail, exponential proposals
+		{0, 1, 3, 3.1},       // tail, uniform proposals
+		{0, 1, 8, 9},         // far tail
+		{10, 2, -inf, 5},     // left tail
+		{5, 3, -20, -10},     // far left tail
+		{100, 0.01, 99, 100}, // left half
+	} {
+		mean, stddev, low, high := tc.mean, tc.stddev, tc.low, tc.high
+		checkKS(t, "TruncatedNormal", 100000, func() float64 {
+			x := rng.TruncatedNormal(mean, stddev, low, high)
+			if x < low || x > high {
+				t.Fatalf("TruncatedNormal(%v, %v, %v, %v) returned out of range value: %v", mean, stddev, low, high, x)
+			}
+			return x
+		}, truncatedNormalCDF(mean, stddev, low, high))
+	}
+
+	// Windows hundreds, or 1e160, standard deviations away must not stall.
+	for i := 0; i < 1000; i++ {
+		if x := rng.TruncatedNormal(0, 1, 500, 501); x < 500 || x > 501 {
+			t.Fatalf("TruncatedNormal(0, 1, 500, 501) returned out of range value: %v", x)
+		}
+		if x := rng.TruncatedNormal(0, 1, -inf, -300); x > -300 {
+			t.Fatalf("TruncatedNormal(0, 1, -Inf, -300) returned out of range value: %v", x)
+		}
+		if x := rng.TruncatedNormal(0, 1e-160, 1, 2); x < 1 || x > 2 {
+			t.Fatalf("TruncatedNormal(0, 1e-160, 1, 2) returned out of range value: %v", x)
+		}
+	}
+
+	safe := random.NewSafeRandom(rand.NewSource(seed))
+	checkKS(t, "SafeRandom.TruncatedNormal", 100000, func() float64 { return safe.TruncatedNormal(1, 2, 4, 10) },
+		truncatedNormalCDF(1, 2, 4, 10))
+
+	checkPanics(t, map[string]func(){
+		"TruncatedNormal(0, 0, -1, 1)":   func() { rng.TruncatedNormal(0, 0, -1, 1) },
+		"TruncatedNormal(0, 1, 1, 1)":    func() { rng.TruncatedNormal(0, 1, 1, 1) },
+		"TruncatedNormal(0, 1, 2, 1)":    func() { rng.TruncatedNormal(0, 1, 2, 1) },
+		"TruncatedNormal(0, 1, NaN, 1)":  func() { rng.TruncatedNormal(0, 1, math.NaN(), 1) },
+		"TruncatedNormal(0, NaN, -1, 1)": func() { rng.TruncatedNormal(0, math.NaN(), -1, 1) },
+		"TruncatedNormal(NaN, 1, 0, 1)":  func() { rng.TruncatedNormal(math.NaN(), 1, 0, 1) },
+		"TruncatedNormal(+Inf, 1, 0, 1)": func() { rng.TruncatedNormal(math.Inf(1), 1, 0, 1) },
+		"TruncatedNormal(-Inf, 1, 0, 1)": func() { rng.TruncatedNormal(math.Inf(-1), 1, 0, 1) },
+		"TruncatedNormal(0, +Inf, 0, 1)": func() { rng.TruncatedNormal(0, math.Inf(1), 0, 1) },
+	})
+}
+
+func Benchmark_StdNormal(b *testing.B) {
+	for n := b.N; n > 0; n-- {
+		rng.StdNormal()
+	}
+}
+
+func Benchmark_Go_NormFloat64(b *testing.B) {
+	r := rand.New(rand.NewSource(seed))
+	for n := b.N; n > 0; n-- {
+		r.NormFloat64()
+	}
+}
+
+func Benchmark_TruncatedNormal(b *testing.B) {
+	for n := b.N; n > 0; n-- {
+		rng.TruncatedNormal(0, 1, 3, math.Inf(1))
+	}
+}
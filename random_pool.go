@@ -0,0 +1,66 @@
+package random
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+)
+
+// Pool hands out independent *Random instances for lock-free concurrent use.
+// Every instance is seeded with its own stream derived from a master seed, so
+// stream k always produces the same sequence for the same master seed and source
+// type. Instances obtained with Get must not be shared between goroutines and
+// should be returned with Put once the caller is done with them.
+//
+// Pool is backed by sync.Pool: instances may be dropped at any garbage collection,
+// in which case Get seeds a fresh instance with the next unused stream.
+type Pool struct {
+	seed      int64
+	newSource func() rand.Source
+	next      atomic.Uint64
+	pool      sync.Pool
+}
+
+// NewPool creates a new Pool whose streams are derived from seed.
+// newSource creates the underlying source of each instance; it is reseeded
+// before use. If newSource is nil, the math/rand source is used.
+func NewPool(seed int64, newSource func() rand.Source) *Pool {
+	if newSource == nil {
+		newSource = func() rand.Source { return rand.NewSource(seed) }
+	}
+	p := &Pool{
+		seed:      seed,
+		newSource: newSource,
+	}
+	p.pool.New = func() any {
+		return p.Stream(p.next.Add(1) - 1)
+	}
+	return p
+}
+
+// Get returns a *Random from the pool, seeding a new stream if the pool is empty.
+func (p *Pool) Get() *Random {
+	return p.pool.Get().(*Random)
+}
+
+// Put returns r to the pool. r must not be used after it is returned.
+func (p *Pool) Put(r *Random) {
+	p.pool.Put(r)
+}
+
+// Stream returns a new *Random seeded with stream k of the master seed.
+// It does not affect the streams handed out by Get.
+func (p *Pool) Stream(k uint64) *Random {
+	r := New(p.newSource())
+	r.Seed(streamSeed(p.seed, k))
+	return r
+}
+
+// streamSeed derives the seed of stream k from the master seed with SplitMix64,
+// so that neighbouring streams start from well-separated states.
+func streamSeed(seed int64, k uint64) int64 {
+	z := uint64(seed) + (k+1)*0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return int64(z ^ (z >> 31))
+}
@@ -0,0 +1,107 @@
+package random_test
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/bofry/random"
+	"github.com/bofry/random/mt19937"
+)
+
+var rng_pool = random.NewPool(seed, nil)
+
+// TestPoolStreamReproducible tests that a stream yields the same sequence for the same master seed.
+func TestPoolStreamReproducible(t *testing.T) {
+	factories := []struct {
+		name      string
+		newSource func() rand.Source
+	}{
+		{"Go", nil},
+		{"MT19937", func() rand.Source { return mt19937.New() }},
+	}
+
+	for _, f := range factories {
+		t.Run(f.name, func(t *testing.T) {
+			a := random.NewPool(seed, f.newSource)
+			b := random.NewPool(seed, f.newSource)
+
+			// Get hands out streams in order on a fresh pool.
+			got := a.Get()
+			want := b.Stream(0)
+			for i := 0; i < 100; i++ {
+				if x, y := got.Uint64(), want.Uint64(); x != y {
+					t.Fatalf("Get/Stream(0) #%d: expected %d, got %d", i, y, x)
+				}
+			}
+
+			for k := uint64(0); k < 8; k++ {
+				x, y := a.Stream(k), b.Stream(k)
+				for i := 0; i < 100; i++ {
+					if v, w := x.Uint64(), y.Uint64(); v != w {
+						t.Fatalf("Stream(%d) #%d: expected %d, got %d", k, i, w, v)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestPoolStreamsDistinct tests that different streams and different master seeds diverge.
+func TestPoolStreamsDistinct(t *testing.T) {
+	p := random.NewPool(seed, nil)
+	seen := make(map[uint64]uint64)
+	for k := uint64(0); k < 1000; k++ {
+		v := p.Stream(k).Uint64()
+		if prev, ok := seen[v]; ok {
+			t.Fatalf("Stream(%d) and Stream(%d) start with the same value %d", prev, k, v)
+		}
+		seen[v] = k
+	}
+
+	q := random.NewPool(seed+1, nil)
+	if p.Stream(0).Uint64() == q.Stream(0).Uint64() {
+		t.Error("different master seeds produced the same stream")
+	}
+}
+
+// TestPoolConcurrent draws from the pool from many goroutines. Run with -race.
+func TestPoolConcurrent(t *testing.T) {
+	p := random.NewPool(seed, nil)
+	var wg sync.WaitGroup
+	for w := 0; w < safeWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < safeRounds; i++ {
+				r := p.Get()
+				if v := r.Intn(10); v < 0 || v >= 10 {
+					t.Errorf("Intn(10) returned out of range value: %d", v)
+				}
+				r.Float64()
+				p.Put(r)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func Benchmark_Test_Pool_Int63(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		r := rng_pool.Get()
+		defer rng_pool.Put(r)
+		for pb.Next() {
+			r.Int63()
+		}
+	})
+}
+
+func Benchmark_Test_Pool_Get_Int63(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			r := rng_pool.Get()
+			r.Int63()
+			rng_pool.Put(r)
+		}
+	})
+}
@@ -0,0 +1,189 @@
+package random
+
+import "math"
+
+// Reservoir keeps a uniform random sample of k items from a stream of unknown length.
+// It implements Li's Algorithm L, which computes how many items to skip between
+// replacements, so it draws O(k(1 + log(n/k))) random numbers for a stream of n items.
+//
+// Reservoir is not safe for concurrent use.
+type Reservoir[T any] struct {
+	r     *Random
+	items []T
+	k     int
+	count int64   // number of items offered so far
+	next  int64   // index of the next item to enter the reservoir
+	w     float64 // Algorithm L acceptance scale
+}
+
+// NewReservoir creates a Reservoir that samples k items using r.
+// Panics if k is negative.
+func NewReservoir[T any](r *Random, k int) *Reservoir[T] {
+	if k < 0 {
+		panic(ErrSampleSize)
+	}
+	return &Reservoir[T]{
+		r:     r,
+		items: make([]T, 0, k),
+		k:     k,
+	}
+}
+
+// Offer presents the next item of the stream to the reservoir.
+func (s *Reservoir[T]) Offer(item T) {
+	i := s.count
+	s.count++
+	if len(s.items) < s.k {
+		s.items = append(s.items, item)
+		if len(s.items) == s.k {
+			s.w = math.Exp(math.Log(s.r.float64Open()) / float64(s.k))
+			s.skip(i)
+		}
+		return
+	}
+	if s.k == 0 || i < s.next {
+		return
+	}
+	s.items[s.r.uint64n(uint64(s.k))] = item
+	s.w *= math.Exp(math.Log(s.r.float64Open()) / float64(s.k))
+	s.skip(i)
+}
+
+// skip schedules the next replacement after the item at index i.
+func (s *Reservoir[T]) skip(i int64) {
+	gap := math.Floor(math.Log(s.r.float64Open())/math.Log1p(-s.w)) + 1
+	if gap >= float64(math.MaxInt64-i) {
+		s.next = math.MaxInt64
+		return
+	}
+	s.next = i + int64(gap)
+}
+
+// Count returns the number of items offered so far.
+func (s *Reservoir[T]) Count() int64 {
+	return s.count
+}
+
+// Result returns a copy of the current sample, which holds min(k, Count()) items
+// in no particular order.
+func (s *Reservoir[T]) Result() []T {
+	return append([]T(nil), s.items...)
+}
+
+// WeightedReservoir keeps a weighted random sample of k distinct items from a stream of
+// unknown length, with the same distribution as SampleWeighted over the whole stream.
+// It implements Efraimidis and Spirakis' A-ExpJ, which jumps over items by accumulating
+// their weights, so it draws O(k log(n/k)) random numbers for a stream of n items.
+//
+// WeightedReservoir is not safe for concurrent use.
+type WeightedReservoir[T any] struct {
+	r     *Random
+	items []T
+	keys  []float64 // log keys; a min-heap parallel to items
+	k     int
+	count int64
+	jump  float64 // remaining weight to skip before the next replacement
+}
+
+// NewWeightedReservoir creates a WeightedReservoir that samples k items using r.
+// Panics if k is negative.
+func NewWeightedReservoir[T any](r *Random, k int) *WeightedReservoir[T] {
+	if k < 0 {
+		panic(ErrSampleSize)
+	}
+	return &WeightedReservoir[T]{
+		r:     r,
+		items: make([]T, 0, k),
+		keys:  make([]float64, 0, k),
+		k:     k,
+	}
+}
+
+// Offer presents the next item of the stream with the given weight.
+// Items with zero weight are never sampled.
+// Panics if weight is negative or non-finite.
+func (s *WeightedReservoir[T]) Offer(item T, weight float64) {
+	if err := checkWeight(weight); err != nil {
+		panic(err)
+	}
+	s.count++
+	if weight == 0 || s.k == 0 {
+		return
+	}
+
+	// Keys are u^(1/w) for u ~ U(0,1), kept as logarithms: log(u) / w.
+	if len(s.items) < s.k {
+		s.items = append(s.items, item)
+		s.keys = append(s.keys, math.Log(s.r.float64Open())/weight)
+		s.up(len(s.keys) - 1)
+		if len(s.items) == s.k {
+			s.setJump()
+		}
+		return
+	}
+
+	s.jump -= weight
+	if s.jump > 0 {
+		return
+	}
+	// The new key is drawn uniformly above the threshold: u ~ U(t^w, 1).
+	tw := math.Exp(s.keys[0] * weight)
+	u := tw + (1-tw)*s.r.float64Open()
+	s.items[0] = item
+	s.keys[0] = math.Log(u) / weight
+	s.down(0)
+	s.setJump()
+}
+
+// setJump draws the total weight to skip before the next replacement,
+// given the current threshold key t: log(U) / log(t).
+func (s *WeightedReservoir[T]) setJump() {
+	s.jump = math.Log(s.r.float64Open()) / s.keys[0]
+}
+
+// Count returns the number of items offered so far.
+func (s *WeightedReservoir[T]) Count() int64 {
+	return s.count
+}
+
+// Result returns a copy of the current sample, which holds at most k items
+// in no particular order.
+func (s *WeightedReservoir[T]) Result() []T {
+	return append([]T(nil), s.items...)
+}
+
+// up restores the heap order after the key at i decreased.
+func (s *WeightedReservoir[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if s.keys[parent] <= s.keys[i] {
+			return
+		}
+		s.swap(i, parent)
+		i = parent
+	}
+}
+
+// down restores the heap order after the key at i increased.
+func (s *WeightedReservoir[T]) down(i int) {
+	n := len(s.keys)
+	for {
+		smallest := i
+		if l := 2*i + 1; l < n && s.keys[l] < s.keys[smallest] {
+			smallest = l
+		}
+		if r := 2*i + 2; r < n && s.keys[r] < s.keys[smallest] {
+			smallest = r
+		}
+		if smallest == i {
+			return
+		}
+		s.swap(i, smallest)
+		i = smallest
+	}
+}
+
+func (s *WeightedReservoir[T]) swap(i, j int) {
+	s.items[i], s.items[j] = s.items[j], s.items[i]
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
@@ -0,0 +1,151 @@
+package random_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/bofry/random"
+)
+
+// TestReservoirUniform tests that every item of the stream ends up in the sample with probability k/n.
+func TestReservoirUniform(t *testing.T) {
+	testCases := []struct {
+		name    string
+		n, k    int
+		buckets int // items are grouped by index into this many buckets
+		rounds  int
+	}{
+		{"Short", 20, 5, 20, 20000},
+		{"Long", 10000, 10, 10, 2000},
+		{"Single", 50, 1, 10, 20000},
+	}
+	rng := random.New(rand.NewSource(seed))
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			counts := make([]int, tc.buckets)
+			for round := 0; round < tc.rounds; round++ {
+				s := random.NewReservoir[int](rng, tc.k)
+				for i := 0; i < tc.n; i++ {
+					s.Offer(i)
+				}
+				result := s.Result()
+				if len(result) != tc.k {
+					t.Fatalf("Result returned %d items, expected %d", len(result), tc.k)
+				}
+				seen := make(map[int]bool)
+				for _, v := range result {
+					if seen[v] {
+						t.Fatalf("Result returned duplicate item %d", v)
+					}
+					seen[v] = true
+					counts[v*tc.buckets/tc.n]++
+				}
+			}
+
+			checkChiSquare(t, "Reservoir", counts, nil)
+		})
+	}
+}
+
+// TestReservoirShortStream tests a stream shorter than the sample size.
+func TestReservoirShortStream(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	s := random.NewReservoir[string](rng, 5)
+	s.Offer("a")
+	s.Offer("b")
+	if got := s.Result(); len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("Result returned %v, expected [a b]", got)
+	}
+	if s.Count() != 2 {
+		t.Errorf("Count returned %d, expected 2", s.Count())
+	}
+
+	empty := random.NewReservoir[string](rng, 0)
+	empty.Offer("a")
+	if got := empty.Result(); len(got) != 0 {
+		t.Errorf("Result of an empty reservoir returned %v", got)
+	}
+}
+
+// TestWeightedReservoirInclusion tests that the inclusion probability of every item matches
+// weighted sampling without replacement over the whole stream.
+func TestWeightedReservoirInclusion(t *testing.T) {
+	const rounds = 100000
+	weights := []float64{1, 2, 0, 3, 4, 0.5, 6, 1, 2, 8}
+	rng := random.New(rand.NewSource(seed))
+
+	for _, k := range []int{1, 3} {
+		included := make([]int, len(weights))
+		for round := 0; round < rounds; round++ {
+			s := random.NewWeightedReservoir[int](rng, k)
+			for i, w := range weights {
+				s.Offer(i, w)
+			}
+			result := s.Result()
+			if len(result) != k {
+				t.Fatalf("Result returned %d items, expected %d", len(result), k)
+			}
+			for _, v := range result {
+				included[v]++
+			}
+		}
+
+		expected := inclusionProbabilities(weights, k)
+		for i := range weights {
+			p := expected[i]
+			tolerance := 5*math.Sqrt(p*(1-p)/rounds) + 1e-9
+			if got := float64(included[i]) / rounds; math.Abs(got-p) > tolerance {
+				t.Errorf("k=%d: item %d included with probability %.4f, expected %.4f", k, i, got, p)
+			}
+		}
+	}
+}
+
+// TestWeightedReservoirLongStream tests that equal weights over a long stream give a uniform sample.
+func TestWeightedReservoirLongStream(t *testing.T) {
+	const (
+		n, k, buckets = 5000, 5, 10
+		rounds        = 2000
+	)
+	rng := random.New(rand.NewSource(seed))
+	counts := make([]int, buckets)
+	for round := 0; round < rounds; round++ {
+		s := random.NewWeightedReservoir[int](rng, k)
+		for i := 0; i < n; i++ {
+			s.Offer(i, 2.5)
+		}
+		for _, v := range s.Result() {
+			counts[v*buckets/n]++
+		}
+	}
+
+	checkChiSquare(t, "WeightedReservoir", counts, nil)
+}
+
+// TestWeightedReservoirPanic tests that invalid weights panic.
+func TestWeightedReservoirPanic(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	s := random.NewWeightedReservoir[int](rng, 2)
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Offer with a negative weight did not panic as expected")
+		}
+	}()
+	s.Offer(1, -1)
+}
+
+func Benchmark_Reservoir_Offer(b *testing.B) {
+	s := random.NewReservoir[int](rng, 100)
+	for n := b.N; n > 0; n-- {
+		s.Offer(n)
+	}
+}
+
+func Benchmark_WeightedReservoir_Offer(b *testing.B) {
+	s := random.NewWeightedReservoir[int](rng, 100)
+	for n := b.N; n > 0; n-- {
+		s.Offer(n, 1)
+	}
+}
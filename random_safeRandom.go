@@ -0,0 +1,676 @@
+package random
+
+import (
+	"math/rand"
+	"sync"
+)
+
+var _ rand.Source64 = (*SafeRandom)(nil) // Ensures SafeRandom complies with rand.Source64
+
+// SafeRandom is a random number generator safe for concurrent use by multiple goroutines.
+// Every method draws from the underlying rand.Rand and therefore mutates its state,
+// so each call holds an exclusive lock for its whole duration and delegates to the
+// wrapped *Random. Composite operations (the *r, *s and *Shuffle helpers) run under
+// a single lock acquisition.
+type SafeRandom struct {
+	mu  sync.Mutex
+	rnd *Random
+}
+
+// NewSafeRandom creates a new SafeRandom instance.
+func NewSafeRandom(src rand.Source) *SafeRandom {
+	return &SafeRandom{
+		rnd: New(src),
+	}
+}
+
+// NewthreadSafeRandom returns a new SafeRandom.
+//
+// Deprecated: use NewSafeRandom instead.
+func NewthreadSafeRandom(src rand.Source) *SafeRandom {
+	return NewSafeRandom(src)
+}
+
+// Seed uses the provided seed value to initialize the generator to a deterministic state.
+func (r *SafeRandom) Seed(seed int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rnd.Seed(seed)
+}
+
+// Int63 returns a non-negative pseudo-random 64-bit integer as an int64.
+func (r *SafeRandom) Int63() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Int63()
+}
+
+// Uint64 returns a non-negative pseudo-random 64-bit integer as a uint64.
+func (r *SafeRandom) Uint64() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Uint64()
+}
+
+// Uint32 returns a pseudo-random 32-bit value as a uint32.
+func (r *SafeRandom) Uint32() uint32 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Uint32()
+}
+
+// Int31 returns a non-negative pseudo-random 31-bit integer as an int32.
+func (r *SafeRandom) Int31() int32 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Int31()
+}
+
+// Int returns a non-negative pseudo-random int.
+func (r *SafeRandom) Int() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Int()
+}
+
+// Int63n returns, as an int64, a non-negative pseudo-random number in [0,n).
+// It panics if n <= 0.
+func (r *SafeRandom) Int63n(n int64) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Int63n(n)
+}
+
+// Int31n returns, as an int32, a non-negative pseudo-random number in [0,n).
+// It panics if n <= 0.
+func (r *SafeRandom) Int31n(n int32) int32 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Int31n(n)
+}
+
+// Intn returns, as an int, a non-negative pseudo-random number in [0,n).
+// It panics if n <= 0.
+func (r *SafeRandom) Intn(n int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Intn(n)
+}
+
+// Float64 returns, as a float64, a pseudo-random number in [0.0,1.0).
+func (r *SafeRandom) Float64() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Float64()
+}
+
+// Float32 returns, as a float32, a pseudo-random number in [0.0,1.0).
+func (r *SafeRandom) Float32() float32 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Float32()
+}
+
+// Perm returns, as a slice of n ints, a pseudo-random permutation of the integers [0,n).
+func (r *SafeRandom) Perm(n int) []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Perm(n)
+}
+
+// Shuffle pseudo-randomizes the order of elements using the provided swap function.
+// The swap function is called while the lock is held and must not use r.
+func (r *SafeRandom) Shuffle(n int, swap func(i, j int)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rnd.Shuffle(n, swap)
+}
+
+// Read generates len(p) random bytes and writes them into p. It always returns len(p) and a nil error.
+func (r *SafeRandom) Read(p []byte) (n int, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Read(p)
+}
+
+// Uint64n returns a non-negative pseudo-random uint64 value in [0, n).
+// Panics if n <= 0.
+func (r *SafeRandom) Uint64n(n uint64) uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Uint64n(n)
+}
+
+// Uint32n returns a non-negative pseudo-random uint32 value in [0, n).
+// Panics if n <= 0.
+func (r *SafeRandom) Uint32n(n uint32) uint32 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Uint32n(n)
+}
+
+// Float64n returns a pseudo-random float64 value in [0.0, n).
+func (r *SafeRandom) Float64n(n float64) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Float64n(n)
+}
+
+// Float32n returns a pseudo-random float32 value in [0.0, n).
+// Panics if n <= 0.
+func (r *SafeRandom) Float32n(n float32) float32 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Float32n(n)
+}
+
+// Int63r generates a pseudo-random int64 between low (inclusive) and high (inclusive).
+func (r *SafeRandom) Int63r(low, high int64) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Int63r(low, high)
+}
+
+// Int63s generates a slice of pseudo-random int64 values between low (inclusive) and high (inclusive).
+func (r *SafeRandom) Int63s(values []int64, low, high int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rnd.Int63s(values, low, high)
+}
+
+// Int63Shuffle shuffles a slice of int64 values.
+func (r *SafeRandom) Int63Shuffle(values []int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rnd.Int63Shuffle(values)
+}
+
+// Uint32r generates a pseudo-random uint32 between low (inclusive) and high (inclusive).
+func (r *SafeRandom) Uint32r(low, high uint32) uint32 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Uint32r(low, high)
+}
+
+// Uint32s generates a slice of pseudo-random uint32 values between low (inclusive) and high (inclusive).
+func (r *SafeRandom) Uint32s(values []uint32, low, high uint32) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rnd.Uint32s(values, low, high)
+}
+
+// Uint32Shuffle shuffles a slice of uint32 values.
+func (r *SafeRandom) Uint32Shuffle(values []uint32) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rnd.Uint32Shuffle(values)
+}
+
+// Uint64r generates a pseudo-random uint64 between low (inclusive) and high (inclusive).
+func (r *SafeRandom) Uint64r(low, high uint64) uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Uint64r(low, high)
+}
+
+// Uint64s generates a slice of pseudo-random uint64 values between low (inclusive) and high (inclusive).
+func (r *SafeRandom) Uint64s(values []uint64, low, high uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rnd.Uint64s(values, low, high)
+}
+
+// Uint64Shuffle shuffles a slice of uint64 values.
+func (r *SafeRandom) Uint64Shuffle(values []uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rnd.Uint64Shuffle(values)
+}
+
+// Int31r generates a pseudo-random int32 between low (inclusive) and high (inclusive).
+func (r *SafeRandom) Int31r(low, high int32) int32 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Int31r(low, high)
+}
+
+// Int31s generates a slice of pseudo-random int32 values between low (inclusive) and high (inclusive).
+func (r *SafeRandom) Int31s(values []int32, low, high int32) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rnd.Int31s(values, low, high)
+}
+
+// Int31Shuffle shuffles a slice of int32 values.
+func (r *SafeRandom) Int31Shuffle(values []int32) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rnd.Int31Shuffle(values)
+}
+
+// Intr generates a pseudo-random int between low (inclusive) and high (inclusive).
+func (r *SafeRandom) Intr(low, high int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Intr(low, high)
+}
+
+// Ints generates a slice of pseudo-random int values between low (inclusive) and high (inclusive).
+func (r *SafeRandom) Ints(values []int, low, high int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rnd.Ints(values, low, high)
+}
+
+// IntShuffle shuffles a slice of int values.
+func (r *SafeRandom) IntShuffle(values []int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rnd.IntShuffle(values)
+}
+
+// Float64r generates a pseudo-random float64 in the range [low, high).
+func (r *SafeRandom) Float64r(low, high float64) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Float64r(low, high)
+}
+
+// Float64s fills a slice with pseudo-random float64 values in the range [low, high).
+func (r *SafeRandom) Float64s(values []float64, low, high float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rnd.Float64s(values, low, high)
+}
+
+// Float64Shuffle shuffles a slice of float64 values.
+func (r *SafeRandom) Float64Shuffle(values []float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rnd.Float64Shuffle(values)
+}
+
+// Float32r generates a pseudo-random float32 in the range [low, high).
+func (r *SafeRandom) Float32r(low, high float32) float32 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Float32r(low, high)
+}
+
+// Float32s fills a slice with pseudo-random float32 values in the range [low, high).
+func (r *SafeRandom) Float32s(values []float32, low, high float32) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rnd.Float32s(values, low, high)
+}
+
+// Float32Shuffle shuffles a slice of float32 values.
+func (r *SafeRandom) Float32Shuffle(values []float32) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rnd.Float32Shuffle(values)
+}
+
+// FlipCoin simulates a coin flip with the given probability p of heads (true).
+func (r *SafeRandom) FlipCoin(p float64) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.FlipCoin(p)
+}
+
+// ----------------------------------------------------------------------------
+// Weighted Random Selection
+// ----------------------------------------------------------------------------
+
+// Float64w randomly picks an index in the range [0, len(w)-1] based on the weights in slice w.
+// The probability of picking index i is w[i] / sum(w); zero weights are never picked.
+// Panics if w is empty, contains negative or non-finite values, or sums to zero or overflows.
+func (r *SafeRandom) Float64w(w []float64) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Float64w(w)
+}
+
+// Float32w randomly picks an index in the range [0, len(w)-1] based on the weights in slice w.
+// The probability of picking index i is w[i] / sum(w); zero weights are never picked.
+// Panics if w is empty, contains negative or non-finite values, or sums to zero or overflows.
+func (r *SafeRandom) Float32w(w []float32) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Float32w(w)
+}
+
+// Uint64w randomly picks an index in the range [0, len(w)-1] based on the weights in slice w.
+// The probability of picking index i is w[i] / sum(w); zero weights are never picked.
+// The selection is exact even when sum(w) exceeds math.MaxUint64.
+// Panics with ErrZeroTotal if all weights are zero, or ErrEmptyWeights if w is empty.
+func (r *SafeRandom) Uint64w(w []uint64) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Uint64w(w)
+}
+
+// Uint32w randomly picks an index in the range [0, len(w)-1] based on the weights in slice w.
+// The probability of picking index i is w[i] / sum(w); zero weights are never picked.
+// Panics with ErrZeroTotal if all weights are zero, or ErrEmptyWeights if w is empty.
+func (r *SafeRandom) Uint32w(w []uint32) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Uint32w(w)
+}
+
+// Int64w randomly picks an index in the range [0, len(w)-1] based on the weights in slice w.
+// The probability of picking index i is w[i] / sum(w); zero weights are never picked.
+// Panics if w is empty, contains negative values or sums to zero.
+func (r *SafeRandom) Int64w(w []int64) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Int64w(w)
+}
+
+// Int32w randomly picks an index in the range [0, len(w)-1] based on the weights in slice w.
+// The probability of picking index i is w[i] / sum(w); zero weights are never picked.
+// Panics if w is empty, contains negative values or sums to zero.
+func (r *SafeRandom) Int32w(w []int32) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Int32w(w)
+}
+
+// Intw randomly picks an index in the range [0, len(w)-1] based on the weights in slice w.
+// The probability of picking index i is w[i] / sum(w); zero weights are never picked.
+// Panics if w is empty, contains negative values or sums to zero.
+func (r *SafeRandom) Intw(w []int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Intw(w)
+}
+
+// TryFloat64w is like Float64w but returns an error instead of panicking on invalid weights.
+func (r *SafeRandom) TryFloat64w(w []float64) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.TryFloat64w(w)
+}
+
+// TryFloat32w is like Float32w but returns an error instead of panicking on invalid weights.
+func (r *SafeRandom) TryFloat32w(w []float32) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.TryFloat32w(w)
+}
+
+// TryUint64w is like Uint64w but returns an error instead of panicking on invalid weights.
+func (r *SafeRandom) TryUint64w(w []uint64) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.TryUint64w(w)
+}
+
+// TryUint32w is like Uint32w but returns an error instead of panicking on invalid weights.
+func (r *SafeRandom) TryUint32w(w []uint32) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.TryUint32w(w)
+}
+
+// TryInt64w is like Int64w but returns an error instead of panicking on invalid weights.
+func (r *SafeRandom) TryInt64w(w []int64) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.TryInt64w(w)
+}
+
+// TryInt32w is like Int32w but returns an error instead of panicking on invalid weights.
+func (r *SafeRandom) TryInt32w(w []int32) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.TryInt32w(w)
+}
+
+// TryIntw is like Intw but returns an error instead of panicking on invalid weights.
+func (r *SafeRandom) TryIntw(w []int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.TryIntw(w)
+}
+
+// SampleWeighted randomly picks k distinct indices in the range [0, len(w)-1] without
+// replacement, with probability proportional to the weights in slice w.
+// Panics if w is invalid as for Float64w, or if k is negative or exceeds the number
+// of positive weights.
+func (r *SafeRandom) SampleWeighted(k int, w []float64) []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.SampleWeighted(k, w)
+}
+
+// TrySampleWeighted is like SampleWeighted but returns an error instead of panicking.
+func (r *SafeRandom) TrySampleWeighted(k int, w []float64) ([]int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.TrySampleWeighted(k, w)
+}
+
+// StdNormal returns a normally distributed float64 with mean 0 and standard deviation 1.
+func (r *SafeRandom) StdNormal() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.StdNormal()
+}
+
+// Normal returns a normally distributed float64 with the given mean and standard deviation.
+// Panics if stddev < 0.
+func (r *SafeRandom) Normal(mean, stddev float64) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Normal(mean, stddev)
+}
+
+// Exponential returns an exponentially distributed float64 with the given rate (inverse mean).
+// Panics if rate <= 0.
+func (r *SafeRandom) Exponential(rate float64) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Exponential(rate)
+}
+
+// Gamma returns a gamma distributed float64 with the given shape and scale.
+// Panics if shape <= 0 or scale <= 0.
+func (r *SafeRandom) Gamma(shape, scale float64) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Gamma(shape, scale)
+}
+
+// Beta returns a beta distributed float64 in [0, 1] with shape parameters a and b.
+// Panics if a <= 0 or b <= 0.
+func (r *SafeRandom) Beta(a, b float64) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Beta(a, b)
+}
+
+// Poisson returns a Poisson distributed int with mean lambda.
+// Panics if lambda < 0 or lambda is not finite.
+func (r *SafeRandom) Poisson(lambda float64) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Poisson(lambda)
+}
+
+// Binomial returns the number of successes in n independent trials with success probability p.
+// Panics if n < 0 or p is not in [0, 1].
+func (r *SafeRandom) Binomial(n int, p float64) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Binomial(n, p)
+}
+
+// Geometric returns the number of failures before the first success in independent
+// trials with success probability p.
+// Panics if p is not in (0, 1].
+func (r *SafeRandom) Geometric(p float64) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Geometric(p)
+}
+
+// NegativeBinomial returns the number of failures before the n-th success in
+// independent trials with success probability p.
+// Panics if n <= 0 or p is not in (0, 1].
+func (r *SafeRandom) NegativeBinomial(n, p float64) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.NegativeBinomial(n, p)
+}
+
+// Hypergeometric returns the number of successes in draws items drawn without
+// replacement from a population of total items, of which successes are successes.
+// Panics unless 0 <= successes <= total and 0 <= draws <= total.
+func (r *SafeRandom) Hypergeometric(total, successes, draws int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Hypergeometric(total, successes, draws)
+}
+
+// Zipf returns a Zipf distributed uint64 in [0, imax], where the probability of k
+// is proportional to (v+k)^(-s).
+// Panics if s <= 1 or v < 1.
+func (r *SafeRandom) Zipf(s, v float64, imax uint64) uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Zipf(s, v, imax)
+}
+
+// Pareto returns a Pareto distributed float64 with scale (minimum) xm and shape alpha.
+// Panics if xm <= 0 or alpha <= 0.
+func (r *SafeRandom) Pareto(xm, alpha float64) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Pareto(xm, alpha)
+}
+
+// LogNormal returns a float64 whose logarithm is normally distributed with
+// mean mu and standard deviation sigma.
+// Panics if sigma < 0.
+func (r *SafeRandom) LogNormal(mu, sigma float64) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.LogNormal(mu, sigma)
+}
+
+// Cauchy returns a Cauchy distributed float64 with location x0 and scale gamma.
+// Panics if gamma <= 0.
+func (r *SafeRandom) Cauchy(x0, gamma float64) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Cauchy(x0, gamma)
+}
+
+// Weibull returns a Weibull distributed float64 with shape k and scale lambda.
+// Panics if k <= 0 or lambda <= 0.
+func (r *SafeRandom) Weibull(k, lambda float64) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Weibull(k, lambda)
+}
+
+// Gumbel returns a Gumbel distributed float64 with location mu and scale beta.
+// Panics if beta <= 0.
+func (r *SafeRandom) Gumbel(mu, beta float64) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Gumbel(mu, beta)
+}
+
+// Laplace returns a Laplace distributed float64 with location mu and scale b.
+// Panics if b <= 0.
+func (r *SafeRandom) Laplace(mu, b float64) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Laplace(mu, b)
+}
+
+// Logistic returns a logistically distributed float64 with location mu and scale s.
+// Panics if s <= 0.
+func (r *SafeRandom) Logistic(mu, s float64) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Logistic(mu, s)
+}
+
+// Rayleigh returns a Rayleigh distributed float64 with scale sigma.
+// Panics if sigma <= 0.
+func (r *SafeRandom) Rayleigh(sigma float64) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Rayleigh(sigma)
+}
+
+// ChiSquared returns a chi-squared distributed float64 with k degrees of freedom.
+// Panics if k <= 0.
+func (r *SafeRandom) ChiSquared(k float64) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.ChiSquared(k)
+}
+
+// StudentT returns a float64 that follows Student's t-distribution with nu degrees of freedom.
+// Panics if nu <= 0.
+func (r *SafeRandom) StudentT(nu float64) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.StudentT(nu)
+}
+
+// FisherF returns a float64 that follows the F-distribution with d1 and d2 degrees of freedom.
+// Panics if d1 <= 0 or d2 <= 0.
+func (r *SafeRandom) FisherF(d1, d2 float64) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.FisherF(d1, d2)
+}
+
+// TruncatedNormal returns a float64 from the normal distribution with the given mean and
+// standard deviation, conditioned on lying in [low, high].
+// Panics if stddev <= 0 or low >= high.
+func (r *SafeRandom) TruncatedNormal(mean, stddev, low, high float64) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.TruncatedNormal(mean, stddev, low, high)
+}
+
+// Triangular returns a float64 from the triangular distribution on [low, high]
+// with the given mode.
+// Panics unless low <= mode <= high and low < high.
+func (r *SafeRandom) Triangular(low, mode, high float64) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Triangular(low, mode, high)
+}
+
+// Dirichlet fills out with a vector drawn from the Dirichlet distribution with
+// concentration parameters alpha.
+// Panics if len(out) != len(alpha), alpha is empty, or any alpha is <= 0 or infinite.
+func (r *SafeRandom) Dirichlet(alpha []float64, out []float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rnd.Dirichlet(alpha, out)
+}
+
+// Multinomial distributes n trials across categories with probability proportional
+// to the weights in probs and stores the count of each category in out.
+// Panics if probs is invalid as for Float64w, if len(out) != len(probs), or if n < 0.
+func (r *SafeRandom) Multinomial(n int, probs []float64, out []int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rnd.Multinomial(n, probs, out)
+}
+
+// TryMultinomial is like Multinomial but returns an error instead of panicking.
+func (r *SafeRandom) TryMultinomial(n int, probs []float64, out []int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.TryMultinomial(n, probs, out)
+}
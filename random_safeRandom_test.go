@@ -0,0 +1,354 @@
+package random_test
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/bofry/random"
+	"github.com/bofry/random/mt19937"
+)
+
+const (
+	safeWorkers = 16   // Number of goroutines hammering a SafeRandom
+	safeRounds  = 2000 // Number of iterations per goroutine
+)
+
+// TestSafeRandomMatchesRandom tests that SafeRandom yields the same sequence as Random for the same seed.
+func TestSafeRandomMatchesRandom(t *testing.T) {
+	plain := random.New(rand.NewSource(seed))
+	safe := random.NewSafeRandom(rand.NewSource(seed))
+
+	for i := 0; i < 1000; i++ {
+		if want, got := plain.Int63(), safe.Int63(); want != got {
+			t.Fatalf("Int63 #%d: expected %d, got %d", i, want, got)
+		}
+		if want, got := plain.Uint64(), safe.Uint64(); want != got {
+			t.Fatalf("Uint64 #%d: expected %d, got %d", i, want, got)
+		}
+		if want, got := plain.Float64(), safe.Float64(); want != got {
+			t.Fatalf("Float64 #%d: expected %v, got %v", i, want, got)
+		}
+		if want, got := plain.Intr(-10, 10), safe.Intr(-10, 10); want != got {
+			t.Fatalf("Intr #%d: expected %d, got %d", i, want, got)
+		}
+	}
+}
+
+// TestSafeRandomConcurrent calls every scalar and weighted method from many goroutines.
+// Run with -race to detect unsynchronized access to the generator state.
+func TestSafeRandomConcurrent(t *testing.T) {
+	sources := []struct {
+		name string
+		rng  *random.SafeRandom
+	}{
+		{"Go", random.NewSafeRandom(rand.NewSource(seed))},
+		{"MT19937", random.NewSafeRandom(mt19937.New())},
+	}
+
+	for _, src := range sources {
+		t.Run(src.name, func(t *testing.T) {
+			r := src.rng
+			var wg sync.WaitGroup
+			for w := 0; w < safeWorkers; w++ {
+				wg.Add(1)
+				go func(w int) {
+					defer wg.Done()
+					buf := make([]byte, 8)
+					for i := 0; i < safeRounds; i++ {
+						if i%500 == 0 {
+							r.Seed(int64(w))
+						}
+						r.Int63()
+						r.Uint64()
+						r.Uint32()
+						r.Int31()
+						r.Int()
+						r.Read(buf)
+						r.Perm(4)
+						r.Shuffle(len(buf), func(i, j int) { buf[i], buf[j] = buf[j], buf[i] })
+						if v := r.Int63n(10); v < 0 || v >= 10 {
+							t.Errorf("Int63n(10) returned out of range value: %d", v)
+						}
+						if v := r.Int31n(10); v < 0 || v >= 10 {
+							t.Errorf("Int31n(10) returned out of range value: %d", v)
+						}
+						if v := r.Intn(10); v < 0 || v >= 10 {
+							t.Errorf("Intn(10) returned out of range value: %d", v)
+						}
+						if v := r.Uint64n(10); v >= 10 {
+							t.Errorf("Uint64n(10) returned out of range value: %d", v)
+						}
+						if v := r.Uint32n(10); v >= 10 {
+							t.Errorf("Uint32n(10) returned out of range value: %d", v)
+						}
+						if v := r.Float64(); v < 0 || v >= 1 {
+							t.Errorf("Float64 returned out of range value: %v", v)
+						}
+						if v := r.Float32(); v < 0 || v >= 1 {
+							t.Errorf("Float32 returned out of range value: %v", v)
+						}
+						if v := r.Float64n(10); v < 0 || v >= 10 {
+							t.Errorf("Float64n(10) returned out of range value: %v", v)
+						}
+						if v := r.Float32n(10); v < 0 || v >= 10 {
+							t.Errorf("Float32n(10) returned out of range value: %v", v)
+						}
+						if v := r.Int63r(-5, 5); v < -5 || v > 5 {
+							t.Errorf("Int63r(-5, 5) returned out of range value: %d", v)
+						}
+						if v := r.Uint64r(5, 10); v < 5 || v > 10 {
+							t.Errorf("Uint64r(5, 10) returned out of range value: %d", v)
+						}
+						if v := r.Uint32r(5, 10); v < 5 || v > 10 {
+							t.Errorf("Uint32r(5, 10) returned out of range value: %d", v)
+						}
+						if v := r.Int31r(-5, 5); v < -5 || v > 5 {
+							t.Errorf("Int31r(-5, 5) returned out of range value: %d", v)
+						}
+						if v := r.Intr(-5, 5); v < -5 || v > 5 {
+							t.Errorf("Intr(-5, 5) returned out of range value: %d", v)
+						}
+						if v := r.Float64r(-5, 5); v < -5 || v >= 5 {
+							t.Errorf("Float64r(-5, 5) returned out of range value: %v", v)
+						}
+						if v := r.Float32r(-5, 5); v < -5 || v >= 5 {
+							t.Errorf("Float32r(-5, 5) returned out of range value: %v", v)
+						}
+						r.FlipCoin(0.5)
+						if v := r.Float64w([]float64{1, 2, 3}); v < 0 || v > 2 {
+							t.Errorf("Float64w returned out of range index: %d", v)
+						}
+						if v := r.Float32w([]float32{1, 2, 3}); v < 0 || v > 2 {
+							t.Errorf("Float32w returned out of range index: %d", v)
+						}
+						if v := r.Uint64w([]uint64{1, 2, 3}); v < 0 || v > 2 {
+							t.Errorf("Uint64w returned out of range index: %d", v)
+						}
+						if v := r.Uint32w([]uint32{1, 2, 3}); v < 0 || v > 2 {
+							t.Errorf("Uint32w returned out of range index: %d", v)
+						}
+						if v := r.Int64w([]int64{1, 2, 3}); v < 0 || v > 2 {
+							t.Errorf("Int64w returned out of range index: %d", v)
+						}
+						if v := r.Int32w([]int32{1, 2, 3}); v < 0 || v > 2 {
+							t.Errorf("Int32w returned out of range index: %d", v)
+						}
+						if v := r.Intw([]int{1, 2, 3}); v < 0 || v > 2 {
+							t.Errorf("Intw returned out of range index: %d", v)
+						}
+					}
+				}(w)
+			}
+			wg.Wait()
+		})
+	}
+}
+
+// TestSafeRandomPanicUnlocks tests that a panicking call does not leave the generator locked.
+func TestSafeRandomPanicUnlocks(t *testing.T) {
+	r := random.NewSafeRandom(rand.NewSource(seed))
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Intn(-1) did not panic as expected")
+			}
+		}()
+		r.Intn(-1)
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		r.Int63()
+		close(done)
+	}()
+	<-done
+}
+
+// TestSafeRandomBulkConcurrent calls every bulk and shuffle method from many goroutines,
+// including empty slices, which used to leave the lock held.
+func TestSafeRandomBulkConcurrent(t *testing.T) {
+	r := random.NewSafeRandom(rand.NewSource(seed))
+
+	var wg sync.WaitGroup
+	for w := 0; w < safeWorkers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < safeRounds/10; i++ {
+				size := i % 8 // includes empty slices
+
+				i63 := make([]int64, size)
+				r.Int63s(i63, -5, 5)
+				r.Int63Shuffle(i63)
+				for _, v := range i63 {
+					if v < -5 || v > 5 {
+						t.Errorf("Int63s generated a value out of range: %d", v)
+					}
+				}
+
+				u32 := make([]uint32, size)
+				r.Uint32s(u32, 5, 10)
+				r.Uint32Shuffle(u32)
+				for _, v := range u32 {
+					if v < 5 || v > 10 {
+						t.Errorf("Uint32s generated a value out of range: %d", v)
+					}
+				}
+
+				u64 := make([]uint64, size)
+				r.Uint64s(u64, 5, 10)
+				r.Uint64Shuffle(u64)
+				for _, v := range u64 {
+					if v < 5 || v > 10 {
+						t.Errorf("Uint64s generated a value out of range: %d", v)
+					}
+				}
+
+				i31 := make([]int32, size)
+				r.Int31s(i31, -5, 5)
+				r.Int31Shuffle(i31)
+				for _, v := range i31 {
+					if v < -5 || v > 5 {
+						t.Errorf("Int31s generated a value out of range: %d", v)
+					}
+				}
+
+				is := make([]int, size)
+				r.Ints(is, -5, 5)
+				r.IntShuffle(is)
+				for _, v := range is {
+					if v < -5 || v > 5 {
+						t.Errorf("Ints generated a value out of range: %d", v)
+					}
+				}
+
+				f64 := make([]float64, size)
+				r.Float64s(f64, -5, 5)
+				r.Float64Shuffle(f64)
+				for _, v := range f64 {
+					if v < -5 || v >= 5 {
+						t.Errorf("Float64s generated a value out of range: %v", v)
+					}
+				}
+
+				f32 := make([]float32, size)
+				r.Float32s(f32, -5, 5)
+				r.Float32Shuffle(f32)
+				for _, v := range f32 {
+					if v < -5 || v >= 5 {
+						t.Errorf("Float32s generated a value out of range: %v", v)
+					}
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+}
+
+// TestSafeRandomBulkAtomic tests that a bulk call draws a contiguous run of the sequence,
+// i.e. it runs under a single lock acquisition.
+func TestSafeRandomBulkAtomic(t *testing.T) {
+	const size = 1000
+
+	ref := make([]int64, 2*size)
+	random.New(rand.NewSource(seed)).Int63s(ref, 0, math.MaxInt64-1)
+
+	for round := 0; round < 20; round++ {
+		r := random.NewSafeRandom(rand.NewSource(seed))
+		a := make([]int64, size)
+		b := make([]int64, size)
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() { defer wg.Done(); r.Int63s(a, 0, math.MaxInt64-1) }()
+		go func() { defer wg.Done(); r.Int63s(b, 0, math.MaxInt64-1) }()
+		wg.Wait()
+
+		ab := append(append([]int64{}, a...), b...)
+		ba := append(append([]int64{}, b...), a...)
+		if !reflect.DeepEqual(ab, ref) && !reflect.DeepEqual(ba, ref) {
+			t.Fatalf("round %d: concurrent Int63s calls interleaved", round)
+		}
+	}
+}
+
+// TestSafeRandomShuffleUniform tests that every SafeRandom *Shuffle function is a uniform permutation.
+func TestSafeRandomShuffleUniform(t *testing.T) {
+	r := random.NewSafeRandom(rand.NewSource(seed))
+
+	checkUniformShuffle(t, "Int63Shuffle", r.Int63Shuffle)
+	checkUniformShuffle(t, "Uint32Shuffle", r.Uint32Shuffle)
+	checkUniformShuffle(t, "Uint64Shuffle", r.Uint64Shuffle)
+	checkUniformShuffle(t, "Int31Shuffle", r.Int31Shuffle)
+	checkUniformShuffle(t, "IntShuffle", r.IntShuffle)
+	checkUniformShuffle(t, "Float64Shuffle", r.Float64Shuffle)
+	checkUniformShuffle(t, "Float32Shuffle", r.Float32Shuffle)
+}
+
+// TestSafeRandomCoversRandom tests that SafeRandom has a counterpart for every method of *Random.
+func TestSafeRandomCoversRandom(t *testing.T) {
+	plain := reflect.TypeOf(&random.Random{})
+	safe := reflect.TypeOf(&random.SafeRandom{})
+	for i := 0; i < plain.NumMethod(); i++ {
+		m := plain.Method(i)
+		sm, ok := safe.MethodByName(m.Name)
+		if !ok {
+			t.Errorf("SafeRandom has no method %s", m.Name)
+			continue
+		}
+		// Compare the signatures without the receiver.
+		want, got := m.Type, sm.Type
+		if want.NumIn() != got.NumIn() || want.NumOut() != got.NumOut() {
+			t.Errorf("SafeRandom.%s has signature %v, expected %v", m.Name, got, want)
+			continue
+		}
+		for j := 1; j < want.NumIn(); j++ {
+			if want.In(j) != got.In(j) {
+				t.Errorf("SafeRandom.%s has signature %v, expected %v", m.Name, got, want)
+			}
+		}
+		for j := 0; j < want.NumOut(); j++ {
+			if want.Out(j) != got.Out(j) {
+				t.Errorf("SafeRandom.%s has signature %v, expected %v", m.Name, got, want)
+			}
+		}
+	}
+}
+
+func Benchmark_Test_ThreadSafe_Seed(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			rng_safe.Seed(seed)
+		}
+	})
+}
+
+func Benchmark_Test_ThreadSafe_Int63(b *testing.B) {
+	rng_safe.Seed(seed)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			rng_safe.Int63()
+		}
+	})
+}
+
+func Benchmark_Test_ThreadSafe_Uint64(b *testing.B) {
+	rng_safe.Seed(seed)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			rng_safe.Uint64()
+		}
+	})
+}
+
+func Benchmark_Test_ThreadSafe_Float64(b *testing.B) {
+	rng_safe.Seed(seed)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			rng_safe.Float64()
+		}
+	})
+}
@@ -0,0 +1,179 @@
+package random
+
+import (
+	"container/heap"
+	"errors"
+	"math"
+	"sort"
+)
+
+// ErrSampleSize is returned when the requested sample size is negative or larger than the population.
+var ErrSampleSize = errors.New("random: invalid sample size")
+
+// Shuffle pseudo-randomizes the order of the elements of s in place with an
+// unbiased Fisher–Yates shuffle driven by r.
+func Shuffle[T any](r *Random, s []T) {
+	for i := len(s) - 1; i > 0; i-- {
+		j := int(r.uint64n(uint64(i + 1)))
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// PartialShuffle moves a uniformly random selection of k elements of s, in random
+// order, to s[:k]. It runs in O(k) and leaves s[k:] holding the remaining elements.
+// Panics if k is negative or larger than len(s).
+func PartialShuffle[T any](r *Random, s []T, k int) {
+	if k < 0 || k > len(s) {
+		panic(ErrSampleSize)
+	}
+	n := len(s)
+	for i := 0; i < k && i < n-1; i++ {
+		j := i + int(r.uint64n(uint64(n-i)))
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// Choice returns a uniformly random element of s.
+// Panics if s is empty.
+func Choice[T any](r *Random, s []T) T {
+	if len(s) == 0 {
+		panic("invalid argument to Choice")
+	}
+	return s[r.uint64n(uint64(len(s)))]
+}
+
+// SampleK returns k distinct elements of s, picked uniformly at random without
+// replacement and in random order. s is not modified. It uses Floyd's algorithm,
+// so it runs in O(k) time and space regardless of len(s).
+// Panics if k is negative or larger than len(s).
+func SampleK[T any](r *Random, s []T, k int) []T {
+	if k < 0 || k > len(s) {
+		panic(ErrSampleSize)
+	}
+
+	// Floyd's algorithm picks a uniform k-subset of positions; the shuffle below
+	// makes their order uniform as well.
+	n := len(s)
+	picked := make([]int, 0, k)
+	var seen map[int]struct{}
+	if k > floydLinearScan {
+		seen = make(map[int]struct{}, k)
+	}
+	contains := func(i int) bool {
+		if seen != nil {
+			_, ok := seen[i]
+			return ok
+		}
+		for _, p := range picked {
+			if p == i {
+				return true
+			}
+		}
+		return false
+	}
+	for j := n - k; j < n; j++ {
+		t := int(r.uint64n(uint64(j + 1)))
+		if contains(t) {
+			t = j
+		}
+		picked = append(picked, t)
+		if seen != nil {
+			seen[t] = struct{}{}
+		}
+	}
+	Shuffle(r, picked)
+
+	result := make([]T, k)
+	for i, p := range picked {
+		result[i] = s[p]
+	}
+	return result
+}
+
+// floydLinearScan is the sample size up to which SampleK looks up picked
+// positions by scanning instead of hashing.
+const floydLinearScan = 32
+
+// SampleWeighted randomly picks k distinct indices in the range [0, len(w)-1] without
+// replacement, with probability proportional to the weights in slice w. Indices are
+// returned in the order in which successive weighted draws would have picked them,
+// so the first index is distributed like Float64w(w). Zero weights are never picked.
+// It runs in O(n log k) using the Efraimidis–Spirakis exponential keys.
+// Panics if w is invalid as for Float64w, or if k is negative or exceeds the number
+// of positive weights.
+func (r *Random) SampleWeighted(k int, w []float64) []int {
+	indices, err := r.TrySampleWeighted(k, w)
+	if err != nil {
+		panic(err)
+	}
+	return indices
+}
+
+// TrySampleWeighted is like SampleWeighted but returns an error instead of panicking.
+func (r *Random) TrySampleWeighted(k int, w []float64) ([]int, error) {
+	if _, err := sumWeights(w); err != nil {
+		return nil, err
+	}
+	positive := 0
+	for _, v := range w {
+		if v > 0 {
+			positive++
+		}
+	}
+	if k < 0 || k > positive {
+		return nil, ErrSampleSize
+	}
+
+	// Item i gets the key E_i / w[i] with E_i ~ Exp(1); the k smallest keys form
+	// the sample. keys is a max-heap holding the k smallest keys seen so far.
+	keys := &weightedKeys{
+		index: make([]int, 0, k),
+		key:   make([]float64, 0, k),
+	}
+	for i, v := range w {
+		if v == 0 || k == 0 {
+			continue
+		}
+		key := -math.Log(1-r.rand.Float64()) / v
+		if keys.Len() < k {
+			heap.Push(keys, weightedKey{i, key})
+		} else if key < keys.key[0] {
+			keys.index[0], keys.key[0] = i, key
+			heap.Fix(keys, 0)
+		}
+	}
+	sort.Sort(sort.Reverse(keys))
+	return keys.index, nil
+}
+
+// weightedKey is an index together with its sampling key.
+type weightedKey struct {
+	index int
+	key   float64
+}
+
+// weightedKeys is a max-heap of sampling keys.
+type weightedKeys struct {
+	index []int
+	key   []float64
+}
+
+func (h *weightedKeys) Len() int           { return len(h.index) }
+func (h *weightedKeys) Less(i, j int) bool { return h.key[i] > h.key[j] }
+func (h *weightedKeys) Swap(i, j int) {
+	h.index[i], h.index[j] = h.index[j], h.index[i]
+	h.key[i], h.key[j] = h.key[j], h.key[i]
+}
+
+func (h *weightedKeys) Push(x any) {
+	k := x.(weightedKey)
+	h.index = append(h.index, k.index)
+	h.key = append(h.key, k.key)
+}
+
+func (h *weightedKeys) Pop() any {
+	n := len(h.index) - 1
+	k := weightedKey{h.index[n], h.key[n]}
+	h.index, h.key = h.index[:n], h.key[:n]
+	return k
+}
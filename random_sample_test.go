@@ -0,0 +1,284 @@
+package random_test
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/bofry/random"
+	"github.com/bofry/random/mt19937"
+)
+
+// TestSampleWeightedInclusion tests that the inclusion probability of every index
+// matches the exact probability of successive weighted draws without replacement.
+func TestSampleWeightedInclusion(t *testing.T) {
+	const rounds = 200000
+	weights := []float64{1, 2, 3, 4, 0, 10}
+	rng := random.New(rand.NewSource(seed))
+
+	for _, k := range []int{1, 2, 3, 5} {
+		included := make([]int, len(weights))
+		first := make([]int, len(weights))
+		for i := 0; i < rounds; i++ {
+			sample := rng.SampleWeighted(k, weights)
+			if len(sample) != k {
+				t.Fatalf("SampleWeighted(%d) returned %d indices", k, len(sample))
+			}
+			seen := make(map[int]bool)
+			for _, index := range sample {
+				if seen[index] {
+					t.Fatalf("SampleWeighted(%d) returned duplicate index %d in %v", k, index, sample)
+				}
+				seen[index] = true
+				included[index]++
+			}
+			first[sample[0]]++
+		}
+
+		expected := inclusionProbabilities(weights, k)
+		var total float64
+		for _, w := range weights {
+			total += w
+		}
+		for i := range weights {
+			// Allow 5 standard deviations of a binomial proportion.
+			p := expected[i]
+			tolerance := 5*math.Sqrt(p*(1-p)/rounds) + 1e-9
+			if got := float64(included[i]) / rounds; math.Abs(got-p) > tolerance {
+				t.Errorf("k=%d: index %d included with probability %.4f, expected %.4f", k, i, got, p)
+			}
+			p = weights[i] / total
+			tolerance = 5*math.Sqrt(p*(1-p)/rounds) + 1e-9
+			if got := float64(first[i]) / rounds; math.Abs(got-p) > tolerance {
+				t.Errorf("k=%d: index %d picked first with probability %.4f, expected %.4f", k, i, got, p)
+			}
+		}
+	}
+}
+
+// inclusionProbabilities enumerates every sequence of k successive weighted draws
+// without replacement and returns the probability of each index being drawn.
+func inclusionProbabilities(weights []float64, k int) []float64 {
+	probs := make([]float64, len(weights))
+	used := make([]bool, len(weights))
+	var walk func(depth int, p float64)
+	walk = func(depth int, p float64) {
+		if depth == k {
+			return
+		}
+		var remaining float64
+		for i, w := range weights {
+			if !used[i] {
+				remaining += w
+			}
+		}
+		for i, w := range weights {
+			if used[i] || w == 0 {
+				continue
+			}
+			q := p * w / remaining
+			probs[i] += q
+			used[i] = true
+			walk(depth+1, q)
+			used[i] = false
+		}
+	}
+	walk(0, 1)
+	return probs
+}
+
+// TestSampleWeightedErrors tests invalid sample sizes and weights.
+func TestSampleWeightedErrors(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	testCases := []struct {
+		name    string
+		k       int
+		weights []float64
+		err     error
+	}{
+		{"Negative k", -1, []float64{1, 2}, random.ErrSampleSize},
+		{"k Exceeds Positive Weights", 2, []float64{1, 0}, random.ErrSampleSize},
+		{"Empty", 0, nil, random.ErrEmptyWeights},
+		{"Negative Weight", 1, []float64{1, -1}, random.ErrNegativeWeight},
+		{"Zero k", 0, []float64{1, 2}, nil},
+		{"All Positive", 2, []float64{1, 2}, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sample, err := rng.TrySampleWeighted(tc.k, tc.weights)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+			if err == nil && len(sample) != tc.k {
+				t.Errorf("expected %d indices, got %d", tc.k, len(sample))
+			}
+		})
+	}
+}
+
+// TestGenericShuffle tests that Shuffle yields all permutations uniformly for any element type.
+func TestGenericShuffle(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	checkUniformShuffle(t, "Shuffle[int]", func(s []int) { random.Shuffle(rng, s) })
+	checkUniformShuffle(t, "Shuffle[float32]", func(s []float32) { random.Shuffle(rng, s) })
+
+	// Degenerate slices must not panic.
+	random.Shuffle(rng, []string{})
+	random.Shuffle(rng, []string{"a"})
+}
+
+// TestChoice runs a chi-square test on Choice.
+func TestChoice(t *testing.T) {
+	const rounds = 50000
+	rng := random.New(rand.NewSource(seed))
+	items := []string{"a", "b", "c", "d", "e"}
+	counts := make([]int, len(items))
+	for i := 0; i < rounds; i++ {
+		counts[random.Choice(rng, items)[0]-'a']++
+	}
+	checkChiSquare(t, "Choice", counts, nil)
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Choice on an empty slice did not panic as expected")
+		}
+	}()
+	random.Choice(rng, []int{})
+}
+
+// checkUniformArrangements runs a chi-square test over all ordered k-arrangements
+// of [0, n) produced by sample, which must all appear and contain distinct values.
+func checkUniformArrangements(t *testing.T, name string, n, k int, sample func() []int) {
+	t.Helper()
+	arrangements := 1
+	for i := 0; i < k; i++ {
+		arrangements *= n - i
+	}
+	rounds := arrangements * 5000
+
+	counts := make(map[int]int)
+	for i := 0; i < rounds; i++ {
+		got := sample()
+		if len(got) != k {
+			t.Fatalf("%s returned %d elements, expected %d", name, len(got), k)
+		}
+		key := 0
+		seen := make(map[int]bool)
+		for _, v := range got {
+			if seen[v] {
+				t.Fatalf("%s returned duplicate element %d in %v", name, v, got)
+			}
+			seen[v] = true
+			key = key*n + v
+		}
+		counts[key]++
+	}
+
+	if len(counts) != arrangements {
+		t.Errorf("%s: expected %d distinct arrangements, got %d", name, arrangements, len(counts))
+		return
+	}
+	if arrangements == 1 {
+		return
+	}
+	observed := make([]int, 0, arrangements)
+	for _, c := range counts {
+		observed = append(observed, c)
+	}
+	checkChiSquare(t, name, observed, nil)
+}
+
+// TestSampleK tests that SampleK picks every ordered k-arrangement uniformly,
+// both below and above the linear-scan threshold.
+func TestSampleK(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	population := []int{0, 1, 2, 3, 4, 5}
+	for _, k := range []int{0, 1, 2, 3, 6} {
+		checkUniformArrangements(t, "SampleK", len(population), k, func() []int {
+			return random.SampleK(rng, population, k)
+		})
+	}
+	if population[0] != 0 || population[5] != 5 {
+		t.Error("SampleK modified its input")
+	}
+
+	// Large k uses a map to look up picked positions.
+	large := make([]int, 1000)
+	for i := range large {
+		large[i] = i
+	}
+	counts := make([]int, len(large))
+	for i := 0; i < 2000; i++ {
+		seen := make(map[int]bool)
+		for _, v := range random.SampleK(rng, large, 100) {
+			if seen[v] {
+				t.Fatalf("SampleK returned duplicate element %d", v)
+			}
+			seen[v] = true
+			counts[v]++
+		}
+	}
+	// Every element is included with probability 1/10, i.e. 200 times on average.
+	for v, c := range counts {
+		if c < 130 || c > 270 {
+			t.Errorf("element %d included %d times, expected about 200", v, c)
+		}
+	}
+}
+
+// TestPartialShuffle tests that the first k positions hold a uniform ordered k-arrangement
+// and that the slice remains a permutation of its input.
+func TestPartialShuffle(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	for _, k := range []int{0, 1, 2, 4} {
+		checkUniformArrangements(t, "PartialShuffle", 4, k, func() []int {
+			s := []int{0, 1, 2, 3}
+			random.PartialShuffle(rng, s, k)
+			seen := make(map[int]bool)
+			for _, v := range s {
+				seen[v] = true
+			}
+			if len(seen) != len(s) {
+				t.Fatalf("PartialShuffle lost elements: %v", s)
+			}
+			return s[:k]
+		})
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("PartialShuffle with k > len(s) did not panic as expected")
+		}
+	}()
+	random.PartialShuffle(rng, []int{1}, 2)
+}
+
+// TestGenericReproducible tests that the generic helpers replay identically from a seeded MT19937.
+func TestGenericReproducible(t *testing.T) {
+	run := func() []int {
+		src := mt19937.New()
+		src.Seed(seed)
+		rng := random.New(src)
+
+		s := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+		random.Shuffle(rng, s)
+		out := append([]int{}, s...)
+		random.PartialShuffle(rng, s, 3)
+		out = append(out, s[:3]...)
+		out = append(out, random.Choice(rng, s))
+		return append(out, random.SampleK(rng, s, 4)...)
+	}
+	if a, b := run(), run(); !reflect.DeepEqual(a, b) {
+		t.Errorf("results differ between runs: %v and %v", a, b)
+	}
+}
+
+func Benchmark_SampleK(b *testing.B) {
+	s := make([]int, 10000)
+	for n := b.N; n > 0; n-- {
+		random.SampleK(rng, s, 10)
+	}
+}
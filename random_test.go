@@ -1,6 +1,7 @@
 package random_test
 
 import (
+	"math"
 	"math/rand"
 	"testing"
 
@@ -12,9 +13,9 @@ import (
 var (
 	seed             = int64(5489)
 	rng              = random.New(rand.NewSource(seed))
-	rng_safe         = random.NewthreadSafeRandom(rand.NewSource(seed))
+	rng_safe         = random.NewSafeRandom(rand.NewSource(seed))
 	rng_mt19937      = random.New(mt19937.New())
-	rng_safe_mt19937 = random.NewthreadSafeRandom(mt19937.New())
+	rng_safe_mt19937 = random.NewSafeRandom(mt19937.New())
 )
 
 func TestRandom(t *testing.T) {
@@ -119,7 +120,7 @@ func TestRead(t *testing.T) {
 }
 
 // Helper function to compare two slices for equality
-func isEqual(a, b []int) bool {
+func isEqual[T comparable](a, b []T) bool {
 	if len(a) != len(b) {
 		return false
 	}
@@ -130,3 +131,47 @@ func isEqual(a, b []int) bool {
 	}
 	return true
 }
+
+// chiSquareCritical approximates the chi-square quantile at p = 0.001 for dof degrees
+// of freedom with the Wilson-Hilferty transformation.
+func chiSquareCritical(dof int) float64 {
+	k := float64(dof)
+	return k * math.Pow(1-2/(9*k)+3.09*math.Sqrt(2/(9*k)), 3)
+}
+
+// checkChiSquare runs a chi-square goodness-of-fit test of the observed counts against
+// weights, which are scaled to the total count; nil weights expect a uniform distribution.
+// Categories with zero weight must never be observed and add no degree of freedom.
+func checkChiSquare(t *testing.T, name string, observed []int, weights []float64) {
+	t.Helper()
+	if weights == nil {
+		weights = make([]float64, len(observed))
+		for i := range weights {
+			weights[i] = 1
+		}
+	}
+	var count int
+	var total float64
+	for i, w := range weights {
+		count += observed[i]
+		total += w
+	}
+
+	var chi2 float64
+	dof := -1
+	for i, w := range weights {
+		if w == 0 {
+			if observed[i] != 0 {
+				t.Errorf("%s: zero weight at index %d observed %d times", name, i, observed[i])
+			}
+			continue
+		}
+		expected := w / total * float64(count)
+		d := float64(observed[i]) - expected
+		chi2 += d * d / expected
+		dof++
+	}
+	if critical := chiSquareCritical(dof); chi2 > critical {
+		t.Errorf("%s: counts %v, chi-square %.2f > %.2f with %d degrees of freedom", name, observed, chi2, critical, dof)
+	}
+}
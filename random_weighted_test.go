@@ -0,0 +1,244 @@
+package random_test
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/bofry/random"
+	"github.com/bofry/random/mt19937"
+)
+
+// TestWeightedChoice tests WeightedChoice with every weight type.
+func TestWeightedChoice(t *testing.T) {
+	const rounds = 100000
+	items := []string{"common", "rare", "never", "epic"}
+	rng := random.New(rand.NewSource(seed))
+
+	testCases := []struct {
+		name     string
+		function func() string
+	}{
+		{"Float64", func() string { return random.WeightedChoice(rng, items, []float64{6, 3, 0, 1}) }},
+		{"Float32", func() string { return random.WeightedChoice(rng, items, []float32{0.6, 0.3, 0, 0.1}) }},
+		{"Uint64", func() string { return random.WeightedChoice(rng, items, []uint64{6, 3, 0, 1}) }},
+		{"Uint32", func() string { return random.WeightedChoice(rng, items, []uint32{6, 3, 0, 1}) }},
+		{"Int64", func() string { return random.WeightedChoice(rng, items, []int64{6, 3, 0, 1}) }},
+		{"Int32", func() string { return random.WeightedChoice(rng, items, []int32{6, 3, 0, 1}) }},
+		{"Int", func() string { return random.WeightedChoice(rng, items, []int{6, 3, 0, 1}) }},
+	}
+	expected := map[string]float64{"common": 0.6, "rare": 0.3, "never": 0, "epic": 0.1}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			counts := make(map[string]int)
+			for i := 0; i < rounds; i++ {
+				counts[tc.function()]++
+			}
+			for item, p := range expected {
+				got := float64(counts[item]) / rounds
+				if got < p-0.01 || got > p+0.01 {
+					t.Errorf("%q picked with probability %.3f, expected %.3f", item, got, p)
+				}
+			}
+		})
+	}
+}
+
+// TestWeightedChoiceErrors tests TryWeightedChoice and TryWeightedKey on invalid input.
+func TestWeightedChoiceErrors(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	if _, err := random.TryWeightedChoice(rng, []int{1, 2}, []int{1}); !errors.Is(err, random.ErrLengthMismatch) {
+		t.Errorf("expected ErrLengthMismatch, got %v", err)
+	}
+	if _, err := random.TryWeightedChoice(rng, []int{1, 2}, []int{1, -1}); !errors.Is(err, random.ErrNegativeWeight) {
+		t.Errorf("expected ErrNegativeWeight, got %v", err)
+	}
+	if _, err := random.TryWeightedKey(rng, map[string]int{}); !errors.Is(err, random.ErrEmptyWeights) {
+		t.Errorf("expected ErrEmptyWeights, got %v", err)
+	}
+	if _, err := random.TryWeightedKey(rng, map[string]uint32{"a": 0}); !errors.Is(err, random.ErrZeroTotal) {
+		t.Errorf("expected ErrZeroTotal, got %v", err)
+	}
+	if _, err := random.TryWeightedKey(rng, map[string]int{"a": 1, "b": -1}); !errors.Is(err, random.ErrNegativeWeight) ||
+		!strings.Contains(err.Error(), "key b") {
+		t.Errorf("expected ErrNegativeWeight at key b, got %v", err)
+	}
+	if _, err := random.TryWeightedKey(rng, map[int]float64{7: math.NaN()}); !errors.Is(err, random.ErrNonFiniteWeight) ||
+		!strings.Contains(err.Error(), "key 7") {
+		t.Errorf("expected ErrNonFiniteWeight at key 7, got %v", err)
+	}
+	less := func(a, b string) bool { return a < b }
+	if _, err := random.NewWeightedKeys(map[string]int64{"a": 1, "b": -1}, less); !errors.Is(err, random.ErrNegativeWeight) ||
+		!strings.Contains(err.Error(), "key b") {
+		t.Errorf("expected ErrNegativeWeight at key b, got %v", err)
+	}
+	if _, err := random.NewWeightedKeys(map[string]float64{}, less); !errors.Is(err, random.ErrEmptyWeights) {
+		t.Errorf("expected ErrEmptyWeights, got %v", err)
+	}
+	if _, err := random.NewWeightedKeys(map[string]float64{"a": math.MaxFloat64, "b": math.MaxFloat64}, less); !errors.Is(err, random.ErrWeightOverflow) {
+		t.Errorf("expected ErrWeightOverflow, got %v", err)
+	}
+}
+
+// TestWeightedKey tests the distribution of WeightedKey with floating-point and integer weights.
+func TestWeightedKey(t *testing.T) {
+	const rounds = 100000
+	rng := random.New(rand.NewSource(seed))
+	expected := map[string]float64{"common": 0.6, "rare": 0.3, "never": 0, "epic": 0.1}
+
+	testCases := []struct {
+		name     string
+		function func() string
+	}{
+		{"Float64", func() string {
+			return random.WeightedKey(rng, map[string]float64{"common": 6, "rare": 3, "never": 0, "epic": 1})
+		}},
+		{"Int32", func() string {
+			return random.WeightedKey(rng, map[string]int32{"common": 6, "rare": 3, "never": 0, "epic": 1})
+		}},
+		{"Uint64", func() string {
+			return random.WeightedKey(rng, map[string]uint64{"common": 6 << 60, "rare": 3 << 60, "never": 0, "epic": 1 << 60})
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			counts := make(map[string]int)
+			for i := 0; i < rounds; i++ {
+				counts[tc.function()]++
+			}
+			for key, p := range expected {
+				got := float64(counts[key]) / rounds
+				if got < p-0.01 || got > p+0.01 {
+					t.Errorf("%q picked with probability %.3f, expected %.3f", key, got, p)
+				}
+			}
+		})
+	}
+}
+
+// TestWeightedKeyDeterministic tests that WeightedKey does not depend on map iteration
+// order, so equally seeded MT19937 sources pick the same keys, the same as WeightedKeys.
+func TestWeightedKeyDeterministic(t *testing.T) {
+	keys := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	forward := make(map[string]int)
+	backward := make(map[string]int)
+	for i, k := range keys {
+		forward[k] = i + 1
+	}
+	for i := len(keys) - 1; i >= 0; i-- {
+		backward[keys[i]] = i + 1
+	}
+	prepared, err := random.NewWeightedKeys(forward, func(a, b string) bool { return a < b })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	a, b, c := mt19937.New(), mt19937.New(), mt19937.New()
+	a.Seed(seed)
+	b.Seed(seed)
+	c.Seed(seed)
+	ra, rb, rc := random.New(a), random.New(b), random.New(c)
+	for i := 0; i < 10000; i++ {
+		x, y, z := random.WeightedKey(ra, forward), random.WeightedKey(rb, backward), prepared.Key(rc)
+		if x != y || x != z {
+			t.Fatalf("draw %d: WeightedKey returned %q and %q for equal maps, WeightedKeys %q", i, x, y, z)
+		}
+	}
+}
+
+// TestWeightedKeysDeterministic tests that WeightedKeys does not depend on map iteration order.
+func TestWeightedKeysDeterministic(t *testing.T) {
+	keys := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	forward := make(map[string]float64)
+	backward := make(map[string]float64)
+	for i, k := range keys {
+		forward[k] = float64(i + 1)
+	}
+	for i := len(keys) - 1; i >= 0; i-- {
+		backward[keys[i]] = float64(i + 1)
+	}
+	less := func(a, b string) bool { return a < b }
+	wf, err := random.NewWeightedKeys(forward, less)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wb, err := random.NewWeightedKeys(backward, less)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if wf.Len() != len(keys) {
+		t.Fatalf("expected Len %d, got %d", len(keys), wf.Len())
+	}
+
+	a := random.New(rand.NewSource(seed))
+	b := random.New(rand.NewSource(seed))
+	counts := make(map[string]int)
+	for i := 0; i < 36000; i++ {
+		x, y := wf.Key(a), wb.Key(b)
+		if x != y {
+			t.Fatalf("draw %d: WeightedKeys returned %q and %q for equal maps", i, x, y)
+		}
+		counts[x]++
+	}
+	// Key i+1 has probability (i+1)/36.
+	for i, k := range keys {
+		expected := float64(i+1) * 1000
+		if got := float64(counts[k]); got < expected*0.9 || got > expected*1.1 {
+			t.Errorf("%q picked %v times, expected about %v", k, got, expected)
+		}
+	}
+}
+
+// TestWeightedAllocs tests that the weighted selection functions do not allocate.
+func TestWeightedAllocs(t *testing.T) {
+	rng := random.New(rand.NewSource(seed))
+	items := []string{"a", "b", "c", "d"}
+	f64, f32 := []float64{2, 2, 4, 2}, []float32{2, 2, 4, 2}
+	u64, u32 := []uint64{2, 2, 4, 2}, []uint32{2, 2, 4, 2}
+	i64, i32, is := []int64{2, 2, 4, 2}, []int32{2, 2, 4, 2}, []int{2, 2, 4, 2}
+	m := map[string]int{"a": 2, "b": 2, "c": 4, "d": 2}
+	keys, _ := random.NewWeightedKeys(m, func(a, b string) bool { return a < b })
+
+	allocs := testing.AllocsPerRun(1000, func() {
+		rng.Float64w(f64)
+		rng.Float32w(f32)
+		rng.Uint64w(u64)
+		rng.Uint32w(u32)
+		rng.Int64w(i64)
+		rng.Int32w(i32)
+		rng.Intw(is)
+		random.WeightedChoice(rng, items, f32)
+		random.WeightedChoice(rng, items, i32)
+		random.WeightedKey(rng, m)
+		keys.Key(rng)
+	})
+	if allocs != 0 {
+		t.Errorf("weighted selection allocated %v times per run, expected 0", allocs)
+	}
+}
+
+func Benchmark_WeightedChoice(b *testing.B) {
+	items := []string{"a", "b", "c", "d"}
+	ws := []int32{2, 2, 4, 2}
+	for n := b.N; n > 0; n-- {
+		random.WeightedChoice(rng, items, ws)
+	}
+}
+
+func Benchmark_WeightedKey(b *testing.B) {
+	m := map[string]int{"a": 2, "b": 2, "c": 4, "d": 2}
+	for n := b.N; n > 0; n-- {
+		random.WeightedKey(rng, m)
+	}
+}
+
+func Benchmark_WeightedKeys(b *testing.B) {
+	keys, _ := random.NewWeightedKeys(map[string]int{"a": 2, "b": 2, "c": 4, "d": 2}, func(a, b string) bool { return a < b })
+	for n := b.N; n > 0; n-- {
+		keys.Key(rng)
+	}
+}